@@ -0,0 +1,289 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/andrewshostak/result-service/client"
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/andrewshostak/result-service/config"
+	"github.com/andrewshostak/result-service/events"
+	"github.com/andrewshostak/result-service/handler"
+	"github.com/andrewshostak/result-service/initializer"
+	loggerinternal "github.com/andrewshostak/result-service/logger"
+	"github.com/andrewshostak/result-service/metrics"
+	"github.com/andrewshostak/result-service/middleware"
+	"github.com/andrewshostak/result-service/repository"
+	"github.com/andrewshostak/result-service/scheduler"
+	"github.com/andrewshostak/result-service/service"
+	"github.com/andrewshostak/result-service/tracing"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"gorm.io/gorm"
+	gormtracing "gorm.io/plugin/opentelemetry/tracing"
+)
+
+const (
+	notifierEventsLocal    = "local"
+	notifierEventsPostgres = "postgres"
+)
+
+// App is the dependency graph of the server. It is shared by cmd/server and integration tests.
+type App struct {
+	Router          *gin.Engine
+	NotifierService *service.NotifierService
+
+	cfg                    config.Config
+	logger                 *zerolog.Logger
+	clock                  clock.Clock
+	matchService           *service.MatchService
+	backfillAliasesService *service.BackfillAliasesService
+	taskScheduler          *scheduler.Task
+	fixtureBatcher         *service.FixturePollingCoordinator
+	notifierInitializer    *initializer.NotifierInitializer
+	notifierStarted        bool
+	matchResultListener    *events.Postgres
+}
+
+// New builds the app. Services, the scheduler and background jobs use the clock, so tests can run them with a fake one.
+func New(cfg config.Config, db *gorm.DB, logger *zerolog.Logger, clock clock.Clock) (*App, error) {
+	r := gin.New()
+
+	notifierHTTPClient := http.Client{Transport: tracing.NewTransport("notifier"), Timeout: cfg.Notifier.DeliveryTimeout}
+	appMetrics := metrics.New()
+
+	if err := db.Use(gormtracing.NewPlugin(gormtracing.WithoutMetrics(), gormtracing.WithoutQueryVariables())); err != nil {
+		return nil, fmt.Errorf("failed to register database tracing: %w", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection pool: %w", err)
+	}
+	appMetrics.RegisterDB(sqlDB, "postgres")
+
+	component := func(name string) *zerolog.Logger {
+		return loggerinternal.Component(logger, name, cfg.Log.ComponentLevels)
+	}
+
+	r.Use(otelgin.Middleware(tracing.ServiceName, otelgin.WithFilter(tracedRequest)))
+	r.Use(middleware.RequestLogger(component("http")), gin.Recovery())
+	r.Use(middleware.Metrics(appMetrics))
+
+	r.GET("/_ah/start", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	r.GET("/metrics", gin.WrapH(appMetrics.Handler()))
+
+	expectedMigrationVersion, err := repository.LatestMigrationVersion(cfg.PG.MigrationsSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the latest migration version: %w", err)
+	}
+
+	quotaTracker := client.NewQuotaTracker(clock, client.QuotaPolicy{
+		Reserve:            cfg.ExternalAPI.FootballAPIQuotaReserve,
+		LowPriorityReserve: cfg.ExternalAPI.FootballAPIQuotaLowPriorityReserve,
+	})
+	footballAPIHTTPClient := http.Client{Transport: tracing.NewTransport("football-api"), Timeout: cfg.ExternalAPI.FootballAPITimeout}
+	footballAPIClient := client.NewFootballAPIClient(
+		&footballAPIHTTPClient,
+		component("football-api"),
+		cfg.ExternalAPI.FootballAPIBaseURL,
+		cfg.ExternalAPI.RapidAPIKey,
+		client.RetryPolicy{
+			MaxRetries: cfg.ExternalAPI.FootballAPIMaxRetries,
+			BaseDelay:  cfg.ExternalAPI.FootballAPIRetryBaseDelay,
+			MaxDelay:   cfg.ExternalAPI.FootballAPIRetryMaxDelay,
+		},
+		client.NewCircuitBreaker(clock, cfg.ExternalAPI.FootballAPIBreakerThreshold, cfg.ExternalAPI.FootballAPIBreakerOpenDuration),
+		quotaTracker,
+		appMetrics,
+		clock,
+	)
+	notifierClient := client.NewNotifierClient(&notifierHTTPClient, component("notifier"))
+
+	fixtureBatcher := service.NewFixturePollingCoordinator(footballAPIClient, component("polling"), clock, cfg.ExternalAPI.FootballAPIBatchWindow)
+	resultProviders := []service.ResultProvider{service.NewFootballAPIResultProvider(fixtureBatcher)}
+	if cfg.ExternalAPI.ResultFeedURL != "" {
+		resultFeedHTTPClient := http.Client{Transport: tracing.NewTransport("result-feed")}
+		resultFeedClient := client.NewResultFeedClient(&resultFeedHTTPClient, component("result-feed"), cfg.ExternalAPI.ResultFeedURL, cfg.ExternalAPI.ResultFeedKey)
+		resultProviders = append(resultProviders, service.NewResultFeedProvider(resultFeedClient))
+	}
+
+	switch cfg.Result.VerificationMode {
+	case service.ResultVerificationOff, service.ResultVerificationDelayed:
+	case service.ResultVerificationProvider:
+		if len(resultProviders) < 2 {
+			return nil, errors.New("result verification with another provider requires RESULT_FEED_URL to be set")
+		}
+	default:
+		return nil, fmt.Errorf("unknown result verification mode: %s", cfg.Result.VerificationMode)
+	}
+
+	if cfg.Notifier.Interval <= 0 {
+		return nil, fmt.Errorf("notifier interval must be positive, got %s", cfg.Notifier.Interval)
+	}
+
+	aliasRepository := repository.NewAliasRepository(db)
+	matchRepository := repository.NewMatchRepository(db)
+	appMetrics.RegisterMatchStatuses(matchRepository)
+	footballAPIFixtureRepository := repository.NewFootballAPIFixtureRepository(db)
+	subscriptionRepository := repository.NewSubscriptionRepository(db)
+	outboxRepository := repository.NewOutboxRepository(db)
+
+	taskScheduler := scheduler.NewTaskScheduler(clock)
+
+	var (
+		matchResultEvents interface {
+			service.ResultPublisher
+			initializer.MatchResultEvents
+		}
+		matchResultListener *events.Postgres
+	)
+	switch cfg.Notifier.Events {
+	case notifierEventsLocal:
+		matchResultEvents = events.NewLocal()
+	case notifierEventsPostgres:
+		matchResultListener = events.NewPostgres(db, events.PgxConnect(repository.ConnectionString(cfg.PG)), component("notifier"), clock)
+		matchResultEvents = matchResultListener
+	default:
+		return nil, fmt.Errorf("unknown notifier events: %s", cfg.Notifier.Events)
+	}
+
+	matchService := service.NewMatchService(
+		aliasRepository,
+		matchRepository,
+		footballAPIFixtureRepository,
+		footballAPIClient,
+		resultProviders,
+		taskScheduler,
+		matchResultEvents,
+		component("polling"),
+		clock,
+		appMetrics,
+		cfg.Result.PollingMaxRetries,
+		cfg.Result.PollingInterval,
+		cfg.Result.PollingFirstAttemptDelay,
+		cfg.Result.PollingMaxDuration,
+		service.LivePolling{
+			Interval:                  cfg.Result.LivePollingInterval,
+			HalfTimeInterval:          cfg.Result.HalfTimeInterval,
+			KnockoutFirstAttemptDelay: cfg.Result.KnockoutFirstAttemptDelay,
+			MaxDuration:               cfg.Result.LiveMaxDuration,
+		},
+		service.ResultVerification{Mode: cfg.Result.VerificationMode, Delay: cfg.Result.VerificationDelay},
+	)
+	subscriptionService := service.NewSubscriptionService(subscriptionRepository, matchRepository, aliasRepository, taskScheduler, component("subscription"), clock)
+	notifierService := service.NewNotifierService(outboxRepository, notifierClient, component("notifier"), clock, appMetrics,
+		service.NotifierConcurrency{
+			Workers:         cfg.Notifier.Workers,
+			PerHost:         cfg.Notifier.PerHostConcurrency,
+			DeliveryTimeout: cfg.Notifier.DeliveryTimeout,
+		},
+	)
+	aliasService := service.NewAliasService(aliasRepository, component("alias"))
+	backfillAliasesService := service.NewBackfillAliasesService(aliasRepository, footballAPIClient, component("backfill-aliases"), clock, cfg.BackfillAliases.Workers)
+	footballAPIQuotaService := service.NewFootballAPIQuotaService(quotaTracker)
+	notifierInitializer := initializer.NewNotifierInitializer(notifierService, matchResultEvents, component("notifier"), clock, appMetrics, cfg.Notifier.Interval)
+	healthService := service.NewHealthService(repository.NewHealthRepository(db), notifierInitializer, footballAPIClient, clock, expectedMigrationVersion)
+
+	matchHandler := handler.NewMatchHandler(matchService)
+	subscriptionHandler := handler.NewSubscriptionHandler(subscriptionService)
+	aliasHandler := handler.NewAliasHandler(aliasService)
+	backfillAliasesHandler := handler.NewBackfillAliasesHandler(backfillAliasesService)
+	footballAPIQuotaHandler := handler.NewFootballAPIQuotaHandler(footballAPIQuotaService)
+	healthHandler := handler.NewHealthHandler(healthService)
+	r.GET("/healthz", healthHandler.Liveness)
+	r.GET("/readyz", healthHandler.Readiness)
+
+	r.Use(middleware.Authorization(cfg.App.HashedAPIKeys, cfg.App.SecretKey))
+
+	v1 := r.Group("/v1")
+	v1.POST("/matches", matchHandler.Create)
+	v1.POST("/subscriptions", subscriptionHandler.Create)
+	v1.DELETE("/subscriptions", subscriptionHandler.Delete)
+	v1.GET("/aliases", aliasHandler.Search)
+	v1.GET("/aliases/export", aliasHandler.Export)
+	v1.POST("/aliases/import", aliasHandler.Import)
+	v1.GET("/backfill-aliases/status", backfillAliasesHandler.Status)
+	v1.GET("/football-api/quota", footballAPIQuotaHandler.Get)
+
+	return &App{
+		Router:                 r,
+		NotifierService:        notifierService,
+		cfg:                    cfg,
+		logger:                 logger,
+		clock:                  clock,
+		matchService:           matchService,
+		backfillAliasesService: backfillAliasesService,
+		taskScheduler:          taskScheduler,
+		fixtureBatcher:         fixtureBatcher,
+		notifierInitializer:    notifierInitializer,
+		matchResultListener:    matchResultListener,
+	}, nil
+}
+
+// Start reschedules result acquiring of scheduled matches and starts background jobs.
+// The notifier loop runs until ctx is cancelled.
+func (a *App) Start(ctx context.Context) error {
+	matchResultScheduleInitializer := initializer.NewMatchResultScheduleInitializer(a.matchService, a.logger)
+	if err := matchResultScheduleInitializer.ReSchedule(ctx); err != nil {
+		return fmt.Errorf("failed to reschedule match result acquiring: %w", err)
+	}
+
+	if a.matchResultListener != nil {
+		go a.matchResultListener.Listen(ctx)
+	}
+
+	a.notifierInitializer.Start(ctx)
+	a.notifierStarted = true
+
+	if a.cfg.BackfillAliases.Enabled {
+		backfillAliasesScheduleInitializer := initializer.NewBackfillAliasesScheduleInitializer(a.backfillAliasesService, a.taskScheduler, a.logger, a.cfg.BackfillAliases.Schedule)
+		if err := backfillAliasesScheduleInitializer.Start(); err != nil {
+			return fmt.Errorf("failed to schedule aliases backfill: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Shutdown stops background jobs and waits for their in-flight work until ctx is done. The context of Start has to be
+// cancelled before, so the notifier loop exits. Polling attempts which are not run are rescheduled on the next start.
+func (a *App) Shutdown(ctx context.Context) error {
+	var shutdownErrors []error
+
+	a.logger.Info().Msg("stopping task scheduler")
+	if err := a.taskScheduler.Stop(ctx); err != nil {
+		shutdownErrors = append(shutdownErrors, fmt.Errorf("task scheduler: %w", err))
+	}
+
+	a.logger.Info().Msg("stopping fixture polling coordinator")
+	if err := a.fixtureBatcher.Stop(ctx); err != nil {
+		shutdownErrors = append(shutdownErrors, fmt.Errorf("fixture polling coordinator: %w", err))
+	}
+
+	if a.notifierStarted {
+		a.logger.Info().Msg("waiting for notifier to stop")
+		if err := a.notifierInitializer.Wait(ctx); err != nil {
+			shutdownErrors = append(shutdownErrors, fmt.Errorf("notifier: %w", err))
+		}
+	}
+
+	a.logger.Info().Msg("background jobs stopped")
+
+	return errors.Join(shutdownErrors...)
+}
+
+// tracedRequest skips spans of probes and metrics scrapes, they are frequent and carry no useful context.
+func tracedRequest(r *http.Request) bool {
+	switch r.URL.Path {
+	case "/metrics", "/healthz", "/readyz", "/_ah/start":
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/andrewshostak/result-service/errs"
+)
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// CircuitBreaker stops calls to an upstream after failureThreshold consecutive failures.
+// After openDuration a single probe call is allowed: its success closes the circuit, its failure opens it again.
+type CircuitBreaker struct {
+	mutex            sync.Mutex
+	clock            clock.Clock
+	state            circuitState
+	failures         uint
+	openedAt         time.Time
+	failureThreshold uint
+	openDuration     time.Duration
+}
+
+func NewCircuitBreaker(clock clock.Clock, failureThreshold uint, openDuration time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{clock: clock, failureThreshold: failureThreshold, openDuration: openDuration}
+}
+
+// Allow returns errs.ErrCircuitOpen when the call must not be made.
+func (b *CircuitBreaker) Allow() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if b.clock.Now().Sub(b.openedAt) < b.openDuration {
+			return errs.ErrCircuitOpen
+		}
+
+		b.state = circuitHalfOpen
+		b.openedAt = b.clock.Now()
+		return nil
+	case circuitHalfOpen:
+		// the probe result may never be reported if its request is cancelled, so another probe is allowed later
+		if b.clock.Now().Sub(b.openedAt) < b.openDuration {
+			return errs.ErrCircuitOpen
+		}
+
+		b.openedAt = b.clock.Now()
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (b *CircuitBreaker) Success() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.state = circuitClosed
+	b.failures = 0
+}
+
+func (b *CircuitBreaker) Failure() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.failures++
+	if b.state == circuitHalfOpen || b.failures >= b.failureThreshold {
+		b.state = circuitOpen
+		b.openedAt = b.clock.Now()
+	}
+}
@@ -0,0 +1,48 @@
+package client_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andrewshostak/result-service/client"
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/andrewshostak/result-service/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker_Allow(t *testing.T) {
+	now := time.Date(2024, time.January, 20, 15, 0, 0, 0, time.UTC)
+
+	t.Run("it should reset consecutive failures on success", func(t *testing.T) {
+		b := client.NewCircuitBreaker(clock.NewFake(now), 2, time.Minute)
+
+		b.Failure()
+		b.Success()
+		b.Failure()
+
+		assert.NoError(t, b.Allow())
+	})
+
+	t.Run("it should allow a single probe when the circuit is half-open", func(t *testing.T) {
+		fakeClock := clock.NewFake(now)
+		b := client.NewCircuitBreaker(fakeClock, 1, time.Minute)
+
+		b.Failure()
+		fakeClock.Advance(time.Minute)
+
+		assert.NoError(t, b.Allow())
+		assert.ErrorIs(t, b.Allow(), errs.ErrCircuitOpen)
+	})
+
+	t.Run("it should allow another probe when the result of the previous one is not reported", func(t *testing.T) {
+		fakeClock := clock.NewFake(now)
+		b := client.NewCircuitBreaker(fakeClock, 1, time.Minute)
+
+		b.Failure()
+		fakeClock.Advance(time.Minute)
+		assert.NoError(t, b.Allow())
+
+		fakeClock.Advance(time.Minute)
+		assert.NoError(t, b.Allow())
+	})
+}
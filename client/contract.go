@@ -3,5 +3,11 @@ package client
 import "github.com/rs/zerolog"
 
 type Logger interface {
+	Debug() *zerolog.Event
 	Error() *zerolog.Event
+	Warn() *zerolog.Event
+}
+
+type Metrics interface {
+	FootballAPIRequest(path string, outcome string, statusCode int)
 }
@@ -4,28 +4,68 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/andrewshostak/result-service/clock"
 	"github.com/andrewshostak/result-service/errs"
+	loggerinternal "github.com/andrewshostak/result-service/logger"
 )
 
 const (
 	fixturesPath = "/v3/fixtures"
 	leaguesPath  = "/v3/leagues"
 	teamsPath    = "/v3/teams"
+	statusPath   = "/v3/status"
 )
 const authHeader = "X-RapidAPI-Key"
 
+// outcomes of football-api request attempts reported to metrics
+const (
+	outcomeSuccess        = "success"
+	outcomeFailure        = "failure"
+	outcomeTransportError = "transport_error"
+	outcomeCircuitOpen    = "circuit_open"
+	outcomeQuotaExhausted = "quota_exhausted"
+)
+
 type FootballAPIClient struct {
-	httpClient *http.Client
-	logger     Logger
-	baseURL    string
-	apiKey     string
+	httpClient     *http.Client
+	logger         Logger
+	baseURL        string
+	apiKey         string
+	retryPolicy    RetryPolicy
+	circuitBreaker *CircuitBreaker
+	quotaTracker   *QuotaTracker
+	metrics        Metrics
+	clock          clock.Clock
 }
 
-func NewFootballAPIClient(httpClient *http.Client, logger Logger, baseURL string, apiKey string) *FootballAPIClient {
-	return &FootballAPIClient{httpClient: httpClient, logger: logger, baseURL: baseURL, apiKey: apiKey}
+func NewFootballAPIClient(
+	httpClient *http.Client,
+	logger Logger,
+	baseURL string,
+	apiKey string,
+	retryPolicy RetryPolicy,
+	circuitBreaker *CircuitBreaker,
+	quotaTracker *QuotaTracker,
+	metrics Metrics,
+	clock clock.Clock,
+) *FootballAPIClient {
+	return &FootballAPIClient{
+		httpClient:     httpClient,
+		logger:         logger,
+		baseURL:        baseURL,
+		apiKey:         apiKey,
+		retryPolicy:    retryPolicy,
+		circuitBreaker: circuitBreaker,
+		quotaTracker:   quotaTracker,
+		metrics:        metrics,
+		clock:          clock,
+	}
 }
 
 func (c *FootballAPIClient) SearchFixtures(ctx context.Context, search FixtureSearch) (*FixturesResponse, error) {
@@ -55,11 +95,19 @@ func (c *FootballAPIClient) SearchFixtures(ctx context.Context, search FixtureSe
 		q.Add("id", strconv.Itoa(int(*search.ID)))
 	}
 
+	if len(search.IDs) > 0 {
+		ids := make([]string, 0, len(search.IDs))
+		for _, id := range search.IDs {
+			ids = append(ids, strconv.Itoa(int(id)))
+		}
+		q.Add("ids", strings.Join(ids, "-"))
+	}
+
 	req.URL.RawQuery = q.Encode()
 
 	req.Header.Set(authHeader, c.apiKey)
 
-	res, err := c.httpClient.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request to get fixtures: %w", err)
 	}
@@ -67,7 +115,7 @@ func (c *FootballAPIClient) SearchFixtures(ctx context.Context, search FixtureSe
 	defer func() {
 		err := res.Body.Close()
 		if err != nil {
-			c.logger.Error().Err(err).Msg("couldn't close response body")
+			loggerinternal.WithRequestID(ctx, c.logger).Error().Err(err).Msg("couldn't close response body")
 		}
 	}()
 
@@ -98,7 +146,7 @@ func (c *FootballAPIClient) SearchLeagues(ctx context.Context, season uint) (*Le
 
 	req.Header.Set(authHeader, c.apiKey)
 
-	res, err := c.httpClient.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request to get leagues: %w", err)
 	}
@@ -106,7 +154,7 @@ func (c *FootballAPIClient) SearchLeagues(ctx context.Context, season uint) (*Le
 	defer func() {
 		err := res.Body.Close()
 		if err != nil {
-			c.logger.Error().Err(err).Msg("couldn't close response body")
+			loggerinternal.WithRequestID(ctx, c.logger).Error().Err(err).Msg("couldn't close response body")
 		}
 	}()
 
@@ -122,6 +170,29 @@ func (c *FootballAPIClient) SearchLeagues(ctx context.Context, season uint) (*Le
 	return nil, fmt.Errorf("%s: %w", fmt.Sprintf("failed to get leagues, status %d", res.StatusCode), errs.ErrUnexpectedAPIFootballStatusCode)
 }
 
+// Status requests the account status, which doesn't count in the daily quota. It is used to check football-api reachability.
+func (c *FootballAPIClient) Status(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+statusPath, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request to get status: %w", err)
+	}
+
+	req.Header.Set(authHeader, c.apiKey)
+
+	res, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request to get status: %w", err)
+	}
+
+	c.discardBody(res)
+
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return fmt.Errorf("%s: %w", fmt.Sprintf("failed to get status, status %d", res.StatusCode), errs.ErrUnexpectedAPIFootballStatusCode)
+}
+
 func (c *FootballAPIClient) SearchTeams(ctx context.Context, search TeamsSearch) (*TeamsResponse, error) {
 	url := c.baseURL + teamsPath
 
@@ -138,7 +209,7 @@ func (c *FootballAPIClient) SearchTeams(ctx context.Context, search TeamsSearch)
 
 	req.Header.Set(authHeader, c.apiKey)
 
-	res, err := c.httpClient.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request to get teams: %w", err)
 	}
@@ -146,7 +217,7 @@ func (c *FootballAPIClient) SearchTeams(ctx context.Context, search TeamsSearch)
 	defer func() {
 		err := res.Body.Close()
 		if err != nil {
-			c.logger.Error().Err(err).Msg("couldn't close response body")
+			loggerinternal.WithRequestID(ctx, c.logger).Error().Err(err).Msg("couldn't close response body")
 		}
 	}()
 
@@ -161,3 +232,89 @@ func (c *FootballAPIClient) SearchTeams(ctx context.Context, search TeamsSearch)
 
 	return nil, fmt.Errorf("%s: %w", fmt.Sprintf("failed to get teams, status %d", res.StatusCode), errs.ErrUnexpectedAPIFootballStatusCode)
 }
+
+// do sends the request and retries it according to the retry policy. Every attempt passes the circuit breaker
+// and the quota check, so an open circuit or a low quota fails the call immediately with errs.ErrCircuitOpen or errs.ErrQuotaExhausted.
+func (c *FootballAPIClient) do(req *http.Request) (*http.Response, error) {
+	priority := priorityFromContext(req.Context())
+
+	for attempt := uint(0); ; attempt++ {
+		if err := c.quotaTracker.Allow(priority); err != nil {
+			c.metrics.FootballAPIRequest(req.URL.Path, outcomeQuotaExhausted, 0)
+			return nil, fmt.Errorf("football api: %w", err)
+		}
+
+		if err := c.circuitBreaker.Allow(); err != nil {
+			c.metrics.FootballAPIRequest(req.URL.Path, outcomeCircuitOpen, 0)
+			return nil, fmt.Errorf("football api: %w", err)
+		}
+
+		start := c.clock.Now()
+		res, err := c.httpClient.Do(req)
+		c.recordRequest(req, res, err)
+		c.logRequest(req, res, c.clock.Now().Sub(start))
+		if err != nil && req.Context().Err() != nil {
+			return nil, err
+		}
+
+		if res != nil {
+			c.quotaTracker.Record(res.Header)
+		}
+
+		if !c.retryPolicy.shouldRetry(res, err) {
+			c.circuitBreaker.Success()
+			return res, nil
+		}
+
+		// 429 means the upstream is reachable, the quota tracker takes care of the rate limits
+		if res == nil || res.StatusCode != http.StatusTooManyRequests {
+			c.circuitBreaker.Failure()
+		}
+
+		if attempt >= c.retryPolicy.MaxRetries {
+			return res, err
+		}
+
+		delay := c.retryPolicy.delay(attempt, res)
+
+		event := loggerinternal.WithRequestID(req.Context(), c.logger).Warn().Uint("attempt", attempt+1).Dur("delay", delay).Str("path", req.URL.Path)
+		if err != nil {
+			event = event.Err(err)
+		} else {
+			event = event.Int("status", res.StatusCode)
+			c.discardBody(res)
+		}
+		event.Msg("football api request failed. retrying")
+
+		if err := sleep(req.Context(), c.clock, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *FootballAPIClient) recordRequest(req *http.Request, res *http.Response, err error) {
+	switch {
+	case err != nil:
+		c.metrics.FootballAPIRequest(req.URL.Path, outcomeTransportError, 0)
+	case res.StatusCode >= http.StatusOK && res.StatusCode < http.StatusMultipleChoices:
+		c.metrics.FootballAPIRequest(req.URL.Path, outcomeSuccess, res.StatusCode)
+	default:
+		c.metrics.FootballAPIRequest(req.URL.Path, outcomeFailure, res.StatusCode)
+	}
+}
+
+func (c *FootballAPIClient) logRequest(req *http.Request, res *http.Response, duration time.Duration) {
+	event := loggerinternal.WithRequestID(req.Context(), c.logger).Debug().Str("path", req.URL.Path).Str("query", req.URL.RawQuery).Dur("duration", duration)
+	if res != nil {
+		event = event.Int("status", res.StatusCode)
+	}
+
+	event.Msg("football api request sent")
+}
+
+func (c *FootballAPIClient) discardBody(res *http.Response) {
+	_, _ = io.Copy(io.Discard, res.Body)
+	if err := res.Body.Close(); err != nil {
+		loggerinternal.WithRequestID(res.Request.Context(), c.logger).Error().Err(err).Msg("couldn't close response body")
+	}
+}
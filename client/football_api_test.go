@@ -0,0 +1,202 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andrewshostak/result-service/client"
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/andrewshostak/result-service/errs"
+	"github.com/andrewshostak/result-service/metrics"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type response struct {
+	status     int
+	retryAfter int
+}
+
+// footballAPI responds with the responses in their order, the last one is repeated.
+type footballAPI struct {
+	*httptest.Server
+	requests atomic.Int32
+}
+
+func newFootballAPI(t *testing.T, responses ...response) *footballAPI {
+	api := &footballAPI{}
+	api.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		i := min(int(api.requests.Add(1)), len(responses)) - 1
+		if responses[i].retryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(responses[i].retryAfter))
+		}
+		w.WriteHeader(responses[i].status)
+	}))
+	t.Cleanup(api.Close)
+
+	return api
+}
+
+func newFootballAPIClient(baseURL string, clock clock.Clock, maxRetries uint, breakerThreshold uint) *client.FootballAPIClient {
+	logger := zerolog.Nop()
+
+	return client.NewFootballAPIClient(
+		http.DefaultClient,
+		&logger,
+		baseURL,
+		"key",
+		client.RetryPolicy{MaxRetries: maxRetries, BaseDelay: time.Second, MaxDelay: time.Minute},
+		client.NewCircuitBreaker(clock, breakerThreshold, 5*time.Minute),
+		client.NewQuotaTracker(clock, client.QuotaPolicy{}),
+		metrics.New(),
+		clock,
+	)
+}
+
+// status calls football-api in the background, the error is sent to the returned channel.
+func status(ctx context.Context, c *client.FootballAPIClient) <-chan error {
+	result := make(chan error, 1)
+	go func() { result <- c.Status(ctx) }()
+
+	return result
+}
+
+// waitForSleep waits until the client sleeps before the next attempt.
+func waitForSleep(t *testing.T, c *clock.Fake) {
+	t.Helper()
+	require.Eventually(t, func() bool { return c.Timers() == 1 }, time.Second, time.Millisecond, "the client doesn't sleep before retrying")
+}
+
+func waitForResult(t *testing.T, result <-chan error) error {
+	t.Helper()
+
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(time.Second):
+		t.Fatal("football api call is not finished")
+		return nil
+	}
+}
+
+func TestFootballAPIClient_Retries(t *testing.T) {
+	now := time.Date(2024, time.January, 20, 15, 0, 0, 0, time.UTC)
+
+	t.Run("it should retry 5xx responses", func(t *testing.T) {
+		api := newFootballAPI(t, response{status: http.StatusInternalServerError}, response{status: http.StatusBadGateway}, response{status: http.StatusOK})
+		fakeClock := clock.NewFake(now)
+
+		result := status(context.Background(), newFootballAPIClient(api.URL, fakeClock, 2, 5))
+		waitForSleep(t, fakeClock)
+		fakeClock.Advance(time.Minute)
+		waitForSleep(t, fakeClock)
+		fakeClock.Advance(time.Minute)
+
+		assert.NoError(t, waitForResult(t, result))
+		assert.EqualValues(t, 3, api.requests.Load())
+	})
+
+	t.Run("it should return the last response when retries are exhausted", func(t *testing.T) {
+		api := newFootballAPI(t, response{status: http.StatusServiceUnavailable})
+		fakeClock := clock.NewFake(now)
+
+		result := status(context.Background(), newFootballAPIClient(api.URL, fakeClock, 1, 5))
+		waitForSleep(t, fakeClock)
+		fakeClock.Advance(time.Minute)
+
+		assert.ErrorIs(t, waitForResult(t, result), errs.ErrUnexpectedAPIFootballStatusCode)
+		assert.EqualValues(t, 2, api.requests.Load())
+	})
+
+	t.Run("it should retry 429 response after Retry-After seconds", func(t *testing.T) {
+		api := newFootballAPI(t, response{status: http.StatusTooManyRequests, retryAfter: 30}, response{status: http.StatusOK})
+		fakeClock := clock.NewFake(now)
+
+		result := status(context.Background(), newFootballAPIClient(api.URL, fakeClock, 2, 5))
+		waitForSleep(t, fakeClock)
+		fakeClock.Advance(29 * time.Second)
+		assert.Equal(t, 1, fakeClock.Timers())
+		assert.EqualValues(t, 1, api.requests.Load())
+
+		fakeClock.Advance(time.Second)
+
+		assert.NoError(t, waitForResult(t, result))
+		assert.EqualValues(t, 2, api.requests.Load())
+	})
+
+	t.Run("it should not retry 4xx responses", func(t *testing.T) {
+		api := newFootballAPI(t, response{status: http.StatusForbidden})
+		fakeClock := clock.NewFake(now)
+
+		err := newFootballAPIClient(api.URL, fakeClock, 2, 5).Status(context.Background())
+
+		assert.ErrorIs(t, err, errs.ErrUnexpectedAPIFootballStatusCode)
+		assert.EqualValues(t, 1, api.requests.Load())
+		assert.Equal(t, 0, fakeClock.Timers())
+	})
+
+	t.Run("it should stop retrying when the context is cancelled during the sleep", func(t *testing.T) {
+		api := newFootballAPI(t, response{status: http.StatusInternalServerError})
+		fakeClock := clock.NewFake(now)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		result := status(ctx, newFootballAPIClient(api.URL, fakeClock, 2, 5))
+		waitForSleep(t, fakeClock)
+		cancel()
+
+		assert.ErrorIs(t, waitForResult(t, result), context.Canceled)
+		assert.EqualValues(t, 1, api.requests.Load())
+		assert.Equal(t, 0, fakeClock.Timers())
+	})
+}
+
+func TestFootballAPIClient_CircuitBreaker(t *testing.T) {
+	now := time.Date(2024, time.January, 20, 15, 0, 0, 0, time.UTC)
+
+	t.Run("it should refuse calls when the failures threshold is reached", func(t *testing.T) {
+		api := newFootballAPI(t, response{status: http.StatusInternalServerError})
+		c := newFootballAPIClient(api.URL, clock.NewFake(now), 0, 2)
+
+		assert.ErrorIs(t, c.Status(context.Background()), errs.ErrUnexpectedAPIFootballStatusCode)
+		assert.ErrorIs(t, c.Status(context.Background()), errs.ErrUnexpectedAPIFootballStatusCode)
+		assert.ErrorIs(t, c.Status(context.Background()), errs.ErrCircuitOpen)
+		assert.EqualValues(t, 2, api.requests.Load())
+	})
+
+	t.Run("it should not count 429 responses as failures", func(t *testing.T) {
+		api := newFootballAPI(t, response{status: http.StatusTooManyRequests}, response{status: http.StatusTooManyRequests}, response{status: http.StatusOK})
+		c := newFootballAPIClient(api.URL, clock.NewFake(now), 0, 1)
+
+		assert.ErrorIs(t, c.Status(context.Background()), errs.ErrUnexpectedAPIFootballStatusCode)
+		assert.ErrorIs(t, c.Status(context.Background()), errs.ErrUnexpectedAPIFootballStatusCode)
+		assert.NoError(t, c.Status(context.Background()))
+		assert.EqualValues(t, 3, api.requests.Load())
+	})
+
+	t.Run("it should probe football api after the open duration", func(t *testing.T) {
+		api := newFootballAPI(t, response{status: http.StatusInternalServerError}, response{status: http.StatusInternalServerError}, response{status: http.StatusOK})
+		fakeClock := clock.NewFake(now)
+		c := newFootballAPIClient(api.URL, fakeClock, 0, 1)
+
+		assert.ErrorIs(t, c.Status(context.Background()), errs.ErrUnexpectedAPIFootballStatusCode)
+		fakeClock.Advance(5*time.Minute - time.Second)
+		assert.ErrorIs(t, c.Status(context.Background()), errs.ErrCircuitOpen)
+
+		// the failed probe opens the circuit again
+		fakeClock.Advance(time.Second)
+		assert.ErrorIs(t, c.Status(context.Background()), errs.ErrUnexpectedAPIFootballStatusCode)
+		assert.ErrorIs(t, c.Status(context.Background()), errs.ErrCircuitOpen)
+
+		// the successful probe closes the circuit
+		fakeClock.Advance(5 * time.Minute)
+		assert.NoError(t, c.Status(context.Background()))
+		assert.NoError(t, c.Status(context.Background()))
+		assert.EqualValues(t, 4, api.requests.Load())
+	})
+}
@@ -5,10 +5,11 @@ type FixturesResponse struct {
 }
 
 type Result struct {
-	Fixture Fixture `json:"fixture"`
-	Teams   Teams   `json:"teams"`
-	Goals   Goals   `json:"goals"`
-	Score   Score   `json:"score"`
+	Fixture Fixture       `json:"fixture"`
+	League  FixtureLeague `json:"league"`
+	Teams   Teams         `json:"teams"`
+	Goals   Goals         `json:"goals"`
+	Score   Score         `json:"score"`
 }
 
 type Fixture struct {
@@ -17,6 +18,12 @@ type Fixture struct {
 	Date   string `json:"date"`
 }
 
+type FixtureLeague struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Round string `json:"round"`
+}
+
 type Teams struct {
 	Home Team `json:"home"`
 	Away Team `json:"away"`
@@ -38,8 +45,9 @@ type Score struct {
 }
 
 type Status struct {
-	Short string `json:"short"`
-	Long  string `json:"long"`
+	Short   string `json:"short"`
+	Long    string `json:"long"`
+	Elapsed *uint  `json:"elapsed"`
 }
 
 type TeamsResponse struct {
@@ -74,6 +82,8 @@ type FixtureSearch struct {
 	Date     *string
 	TeamID   *uint
 	ID       *uint
+	// IDs searches up to 20 fixtures by their ids
+	IDs []uint
 }
 
 type TeamsSearch struct {
@@ -81,6 +91,20 @@ type TeamsSearch struct {
 	League uint
 }
 
+type ResultFeedResponse struct {
+	Matches []ResultFeedMatch `json:"matches"`
+}
+
+type ResultFeedMatch struct {
+	ID        string `json:"id"`
+	HomeTeam  string `json:"home_team"`
+	AwayTeam  string `json:"away_team"`
+	StartsAt  string `json:"starts_at"`
+	Status    string `json:"status"`
+	HomeGoals uint   `json:"home_goals"`
+	AwayGoals uint   `json:"away_goals"`
+}
+
 type Notification struct {
 	Url  string
 	Key  string
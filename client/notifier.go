@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/andrewshostak/result-service/errs"
+	loggerinternal "github.com/andrewshostak/result-service/logger"
 )
 
 const notificationAuthHeader = "Authorization"
@@ -47,7 +48,7 @@ func (c *NotifierClient) Notify(ctx context.Context, notification Notification)
 	defer func() {
 		err := res.Body.Close()
 		if err != nil {
-			c.logger.Error().Err(err).Msg("couldn't close response body")
+			loggerinternal.WithRequestID(ctx, c.logger).Error().Err(err).Msg("couldn't close response body")
 		}
 	}()
 
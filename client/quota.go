@@ -0,0 +1,166 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/andrewshostak/result-service/errs"
+)
+
+const (
+	dailyLimitHeader      = "X-RateLimit-Requests-Limit"
+	dailyRemainingHeader  = "X-RateLimit-Requests-Remaining"
+	minuteLimitHeader     = "X-RateLimit-Limit"
+	minuteRemainingHeader = "X-RateLimit-Remaining"
+)
+
+// Priority defines which requests are still sent when football-api quota runs low.
+type Priority int
+
+const (
+	// PriorityLow is used by background jobs, e.g. aliases backfill.
+	PriorityLow Priority = iota
+	// PriorityNormal is the default priority.
+	PriorityNormal
+	// PriorityUrgent is used for match result polling.
+	PriorityUrgent
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityUrgent:
+		return "urgent"
+	default:
+		return "normal"
+	}
+}
+
+type priorityKey struct{}
+
+// WithPriority returns a context whose football-api requests are sent with the priority.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+func priorityFromContext(ctx context.Context) Priority {
+	if priority, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return priority
+	}
+
+	return PriorityNormal
+}
+
+// Quota is the last known football-api quota. A negative value means the header was not received.
+type Quota struct {
+	DailyLimit      int
+	DailyRemaining  int
+	MinuteLimit     int
+	MinuteRemaining int
+	UpdatedAt       time.Time
+}
+
+// QuotaPolicy defines the daily quota which is kept for requests with higher priority.
+// Normal requests are refused when daily remaining quota is below Reserve, low priority requests - below LowPriorityReserve.
+// Urgent requests are refused only when the daily quota is used.
+type QuotaPolicy struct {
+	Reserve            uint
+	LowPriorityReserve uint
+}
+
+// QuotaTracker records quota headers of football-api responses and decides whether a request can be sent.
+type QuotaTracker struct {
+	mutex  sync.RWMutex
+	clock  clock.Clock
+	quota  *Quota
+	policy QuotaPolicy
+}
+
+func NewQuotaTracker(clock clock.Clock, policy QuotaPolicy) *QuotaTracker {
+	return &QuotaTracker{clock: clock, policy: policy}
+}
+
+// Quota returns the last known quota or nil when football-api is not called yet.
+func (t *QuotaTracker) Quota() *Quota {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if t.quota == nil {
+		return nil
+	}
+
+	quota := *t.quota
+
+	return &quota
+}
+
+func (t *QuotaTracker) Record(header http.Header) {
+	quota := Quota{
+		DailyLimit:      headerInt(header, dailyLimitHeader),
+		DailyRemaining:  headerInt(header, dailyRemainingHeader),
+		MinuteLimit:     headerInt(header, minuteLimitHeader),
+		MinuteRemaining: headerInt(header, minuteRemainingHeader),
+		UpdatedAt:       t.clock.Now().UTC(),
+	}
+
+	if quota.DailyRemaining < 0 && quota.MinuteRemaining < 0 {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.quota = &quota
+}
+
+// Allow returns errs.ErrQuotaExhausted when the request with the priority must not be sent.
+// Daily quota is reset at midnight UTC and minute quota - every minute, so outdated values are ignored.
+func (t *QuotaTracker) Allow(priority Priority) error {
+	quota := t.Quota()
+	if quota == nil {
+		return nil
+	}
+
+	now := t.clock.Now().UTC()
+
+	if quota.MinuteRemaining == 0 && now.Sub(quota.UpdatedAt) < time.Minute && priority != PriorityUrgent {
+		return fmt.Errorf("minute quota is used, %s priority request is refused: %w", priority, errs.ErrQuotaExhausted)
+	}
+
+	if quota.DailyRemaining < 0 || quota.UpdatedAt.Before(now.Truncate(24*time.Hour)) {
+		return nil
+	}
+
+	if quota.DailyRemaining == 0 {
+		return fmt.Errorf("daily quota is used, %s priority request is refused: %w", priority, errs.ErrQuotaExhausted)
+	}
+
+	reserve := 0
+	switch priority {
+	case PriorityLow:
+		reserve = int(t.policy.LowPriorityReserve)
+	case PriorityNormal:
+		reserve = int(t.policy.Reserve)
+	}
+
+	if quota.DailyRemaining <= reserve {
+		return fmt.Errorf("daily quota remaining %d, %s priority request is refused: %w", quota.DailyRemaining, priority, errs.ErrQuotaExhausted)
+	}
+
+	return nil
+}
+
+func headerInt(header http.Header, key string) int {
+	value, err := strconv.Atoi(header.Get(key))
+	if err != nil {
+		return -1
+	}
+
+	return value
+}
@@ -0,0 +1,100 @@
+package client_test
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/andrewshostak/result-service/client"
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/andrewshostak/result-service/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaTracker_Allow(t *testing.T) {
+	recordedAt := time.Date(2024, time.January, 20, 23, 59, 0, 0, time.UTC)
+	policy := client.QuotaPolicy{Reserve: 100, LowPriorityReserve: 500}
+	priorities := []client.Priority{client.PriorityLow, client.PriorityNormal, client.PriorityUrgent}
+
+	header := func(dailyRemaining int, minuteRemaining int) http.Header {
+		h := http.Header{}
+		if dailyRemaining >= 0 {
+			h.Set("X-RateLimit-Requests-Remaining", strconv.Itoa(dailyRemaining))
+		}
+		if minuteRemaining >= 0 {
+			h.Set("X-RateLimit-Remaining", strconv.Itoa(minuteRemaining))
+		}
+		return h
+	}
+
+	tests := []struct {
+		name    string
+		header  http.Header
+		elapsed time.Duration
+		allowed []client.Priority
+	}{
+		{
+			name:    "it should allow all requests when the quota is not received",
+			header:  header(-1, -1),
+			allowed: priorities,
+		},
+		{
+			name:    "it should allow all requests when the daily quota is above the reserves",
+			header:  header(501, 10),
+			allowed: priorities,
+		},
+		{
+			name:    "it should refuse low priority requests when the daily quota is within the low priority reserve",
+			header:  header(500, 10),
+			allowed: []client.Priority{client.PriorityNormal, client.PriorityUrgent},
+		},
+		{
+			name:    "it should allow only urgent requests when the daily quota is within the reserve",
+			header:  header(100, 10),
+			allowed: []client.Priority{client.PriorityUrgent},
+		},
+		{
+			name:    "it should refuse all requests when the daily quota is used",
+			header:  header(0, 10),
+			allowed: nil,
+		},
+		{
+			name:    "it should allow only urgent requests when the minute quota is used",
+			header:  header(1000, 0),
+			allowed: []client.Priority{client.PriorityUrgent},
+		},
+		{
+			name:    "it should allow all requests when the used minute quota is expired",
+			header:  header(1000, 0),
+			elapsed: time.Minute,
+			allowed: priorities,
+		},
+		{
+			name:    "it should ignore the daily quota received before midnight",
+			header:  header(0, 10),
+			elapsed: time.Minute,
+			allowed: priorities,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClock := clock.NewFake(recordedAt)
+			tracker := client.NewQuotaTracker(fakeClock, policy)
+
+			tracker.Record(tt.header)
+			fakeClock.Advance(tt.elapsed)
+
+			for _, priority := range priorities {
+				err := tracker.Allow(priority)
+				if slices.Contains(tt.allowed, priority) {
+					assert.NoError(t, err, priority.String())
+				} else {
+					assert.ErrorIs(t, err, errs.ErrQuotaExhausted, priority.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/andrewshostak/result-service/errs"
+	loggerinternal "github.com/andrewshostak/result-service/logger"
+)
+
+const resultFeedAuthHeader = "Authorization"
+
+// ResultFeedClient reads match results from a generic JSON feed.
+// The feed returns matches of a date: GET {url}?date=2006-01-02.
+type ResultFeedClient struct {
+	httpClient *http.Client
+	logger     Logger
+	url        string
+	apiKey     string
+}
+
+func NewResultFeedClient(httpClient *http.Client, logger Logger, url string, apiKey string) *ResultFeedClient {
+	return &ResultFeedClient{httpClient: httpClient, logger: logger, url: url, apiKey: apiKey}
+}
+
+func (c *ResultFeedClient) SearchMatches(ctx context.Context, date string) (*ResultFeedResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request to get result feed matches: %w", err)
+	}
+
+	q := req.URL.Query()
+	q.Add("date", date)
+
+	req.URL.RawQuery = q.Encode()
+
+	if c.apiKey != "" {
+		req.Header.Set(resultFeedAuthHeader, c.apiKey)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to get result feed matches: %w", err)
+	}
+
+	defer func() {
+		err := res.Body.Close()
+		if err != nil {
+			loggerinternal.WithRequestID(ctx, c.logger).Error().Err(err).Msg("couldn't close response body")
+		}
+	}()
+
+	if res.StatusCode == http.StatusOK {
+		var body ResultFeedResponse
+		if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+			return nil, fmt.Errorf("failed to decode get result feed matches response body: %w", err)
+		}
+
+		return &body, nil
+	}
+
+	return nil, fmt.Errorf("%s: %w", fmt.Sprintf("failed to get result feed matches, status %d", res.StatusCode), errs.ErrUnexpectedResultFeedStatusCode)
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/andrewshostak/result-service/clock"
+)
+
+// RetryPolicy configures retries within a single call. Transport errors, 5xx and 429 responses are retried.
+// 429 responses are not counted as circuit breaker failures, the quota tracker refuses requests when the quota runs low.
+type RetryPolicy struct {
+	MaxRetries uint
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+func (p RetryPolicy) shouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError
+}
+
+// delay returns exponential backoff with full jitter. Retry-After header of 429 response is respected.
+func (p RetryPolicy) delay(attempt uint, res *http.Response) time.Duration {
+	if res != nil && res.StatusCode == http.StatusTooManyRequests {
+		if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			return min(time.Duration(seconds)*time.Second, p.MaxDelay)
+		}
+	}
+
+	backoff := min(p.BaseDelay<<attempt, p.MaxDelay)
+	if backoff <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(backoff) + 1))
+}
+
+func sleep(ctx context.Context, clock clock.Clock, d time.Duration) error {
+	done := make(chan struct{})
+	timer := clock.AfterFunc(d, func() { close(done) })
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-done:
+		return nil
+	}
+}
@@ -0,0 +1,170 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Fake is a clock which is moved only by Set and Advance. Timers and tickers due by the new time fire in order of their time,
+// timer functions are called synchronously, so their effects are visible when Advance returns.
+type Fake struct {
+	mutex   sync.Mutex
+	now     time.Time
+	timers  []*fakeTimer
+	tickers []*fakeTicker
+}
+
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+func (c *Fake) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.now
+}
+
+func (c *Fake) AfterFunc(d time.Duration, f func()) Timer {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	timer := &fakeTimer{clock: c, at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, timer)
+
+	return timer
+}
+
+func (c *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ticker := &fakeTicker{clock: c, period: d, next: c.now.Add(d), c: make(chan time.Time, 1)}
+	c.tickers = append(c.tickers, ticker)
+
+	return ticker
+}
+
+// Timers returns the number of timers which have not fired or been stopped yet.
+// Tests use it to wait until a goroutine starts waiting for a timer.
+func (c *Fake) Timers() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.timers)
+}
+
+// Advance moves the clock forward by d.
+func (c *Fake) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Set moves the clock to t and fires timers and tickers which are due by t, including timers created by fired functions.
+// Timers created with a non-positive duration fire on the next Set or Advance, e.g. Advance(0).
+func (c *Fake) Set(t time.Time) {
+	for {
+		c.mutex.Lock()
+		timer, ticker, at := c.next()
+		if at.IsZero() || at.After(t) {
+			c.now = t
+			c.mutex.Unlock()
+
+			return
+		}
+
+		if at.After(c.now) {
+			c.now = at
+		}
+
+		if timer != nil {
+			c.removeTimer(timer)
+			c.mutex.Unlock()
+			timer.f()
+
+			continue
+		}
+
+		ticker.next = ticker.next.Add(ticker.period)
+		c.mutex.Unlock()
+
+		// ticks are dropped for a slow receiver the same way time.Ticker does
+		select {
+		case ticker.c <- at:
+		default:
+		}
+	}
+}
+
+// next returns the earliest timer or ticker. It must be called with the mutex locked.
+func (c *Fake) next() (*fakeTimer, *fakeTicker, time.Time) {
+	var (
+		timer  *fakeTimer
+		ticker *fakeTicker
+		at     time.Time
+	)
+
+	for _, t := range c.timers {
+		if at.IsZero() || t.at.Before(at) {
+			timer, at = t, t.at
+		}
+	}
+
+	for _, t := range c.tickers {
+		if at.IsZero() || t.next.Before(at) {
+			timer, ticker, at = nil, t, t.next
+		}
+	}
+
+	return timer, ticker, at
+}
+
+func (c *Fake) removeTimer(timer *fakeTimer) bool {
+	for i, t := range c.timers {
+		if t == timer {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+type fakeTimer struct {
+	clock *Fake
+	at    time.Time
+	f     func()
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+
+	return t.clock.removeTimer(t)
+}
+
+type fakeTicker struct {
+	clock  *Fake
+	period time.Duration
+	next   time.Time
+	c      chan time.Time
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+
+	for i, ticker := range t.clock.tickers {
+		if ticker == t {
+			t.clock.tickers = append(t.clock.tickers[:i], t.clock.tickers[i+1:]...)
+			return
+		}
+	}
+}
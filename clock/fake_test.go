@@ -0,0 +1,69 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFake_Advance(t *testing.T) {
+	start := time.Date(2024, time.January, 20, 15, 0, 0, 0, time.UTC)
+
+	t.Run("it should fire due timers in order at their time", func(t *testing.T) {
+		c := clock.NewFake(start)
+
+		var fired []time.Time
+		c.AfterFunc(2*time.Minute, func() { fired = append(fired, c.Now()) })
+		c.AfterFunc(time.Minute, func() { fired = append(fired, c.Now()) })
+		c.AfterFunc(3*time.Minute, func() { fired = append(fired, c.Now()) })
+
+		c.Advance(2 * time.Minute)
+
+		assert.Equal(t, []time.Time{start.Add(time.Minute), start.Add(2 * time.Minute)}, fired)
+		assert.Equal(t, start.Add(2*time.Minute), c.Now())
+	})
+
+	t.Run("it should fire timers created by fired functions", func(t *testing.T) {
+		c := clock.NewFake(start)
+
+		calls := 0
+		var f func()
+		f = func() {
+			calls++
+			c.AfterFunc(time.Minute, f)
+		}
+		c.AfterFunc(time.Minute, f)
+
+		c.Advance(5 * time.Minute)
+
+		assert.Equal(t, 5, calls)
+	})
+
+	t.Run("it should not fire stopped timers", func(t *testing.T) {
+		c := clock.NewFake(start)
+
+		timer := c.AfterFunc(time.Minute, func() { t.Fatal("stopped timer fired") })
+		assert.Equal(t, 1, c.Timers())
+
+		assert.True(t, timer.Stop())
+		assert.Equal(t, 0, c.Timers())
+		assert.False(t, timer.Stop())
+		c.Advance(time.Hour)
+	})
+
+	t.Run("it should send ticks and drop them for a slow receiver", func(t *testing.T) {
+		c := clock.NewFake(start)
+
+		ticker := c.NewTicker(time.Minute)
+		c.Advance(3 * time.Minute)
+
+		assert.Equal(t, start.Add(time.Minute), <-ticker.C())
+		assert.Len(t, ticker.C(), 0)
+
+		ticker.Stop()
+		c.Advance(time.Minute)
+		assert.Len(t, ticker.C(), 0)
+	})
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/andrewshostak/result-service/config"
+	"github.com/andrewshostak/result-service/errs"
+	loggerinternal "github.com/andrewshostak/result-service/logger"
+	"github.com/andrewshostak/result-service/repository"
+	"github.com/andrewshostak/result-service/service"
+	"github.com/spf13/cobra"
+)
+
+func main() {
+	rootCmd := &cobra.Command{
+		Use:   "aliases",
+		Short: "Exports and imports teams with their aliases and football-api ids",
+	}
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Exports teams with aliases to a file or stdout",
+		Run:   runExport,
+	}
+	exportCmd.Flags().String("format", service.AliasFileFormatJSON, "file format: csv or json")
+	exportCmd.Flags().String("output", "", "file path, stdout is used when empty")
+
+	importCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Imports teams with aliases from a file or stdin in one transaction",
+		Run:   runImport,
+	}
+	importCmd.Flags().String("format", service.AliasFileFormatJSON, "file format: csv or json")
+	importCmd.Flags().String("input", "", "file path, stdin is used when empty")
+
+	rootCmd.AddCommand(exportCmd, importCmd)
+
+	if err := rootCmd.Execute(); err != nil {
+		panic(err)
+	}
+}
+
+func runExport(cmd *cobra.Command, _ []string) {
+	format := getFormat(cmd)
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		panic(err)
+	}
+
+	aliasService := newAliasService()
+
+	teams, err := aliasService.Export(context.Background())
+	if err != nil {
+		panic(err)
+	}
+
+	var w io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			panic(fmt.Errorf("failed to create %s file: %w", output, err))
+		}
+		defer file.Close()
+
+		w = file
+	}
+
+	if err := service.WriteTeamAliases(w, format, teams); err != nil {
+		panic(err)
+	}
+}
+
+func runImport(cmd *cobra.Command, _ []string) {
+	format := getFormat(cmd)
+
+	input, err := cmd.Flags().GetString("input")
+	if err != nil {
+		panic(err)
+	}
+
+	var r io.Reader = os.Stdin
+	if input != "" {
+		file, err := os.Open(input)
+		if err != nil {
+			panic(fmt.Errorf("failed to open %s file: %w", input, err))
+		}
+		defer file.Close()
+
+		r = file
+	}
+
+	teams, err := service.ReadTeamAliases(r, format)
+	if err != nil {
+		panic(err)
+	}
+
+	aliasService := newAliasService()
+
+	result, err := aliasService.Import(context.Background(), teams)
+	if errors.As(err, &errs.AliasImportConflictError{}) {
+		for _, conflict := range result.Conflicts {
+			fmt.Printf("conflict: team_id %d, alias %q: %s\n", conflict.TeamID, conflict.Alias, conflict.Reason)
+		}
+
+		fmt.Println("nothing is imported")
+		os.Exit(1)
+	}
+
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("created teams: %d, aliases: %d, football api teams: %d\n", result.CreatedTeams, result.CreatedAliases, result.CreatedFootballAPITeams)
+}
+
+func getFormat(cmd *cobra.Command) string {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		panic(err)
+	}
+
+	if format != service.AliasFileFormatCSV && format != service.AliasFileFormatJSON {
+		panic(fmt.Errorf("format flag must be %s or %s", service.AliasFileFormatCSV, service.AliasFileFormatJSON))
+	}
+
+	return format
+}
+
+func newAliasService() *service.AliasService {
+	cfg := config.Parse()
+
+	logger, err := loggerinternal.SetupLogger(cfg.Log)
+	if err != nil {
+		panic(err)
+	}
+
+	db := repository.EstablishDatabaseConnection(cfg)
+
+	aliasRepository := repository.NewAliasRepository(db)
+
+	return service.NewAliasService(aliasRepository, logger)
+}
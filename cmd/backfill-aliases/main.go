@@ -3,11 +3,17 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/andrewshostak/result-service/client"
+	"github.com/andrewshostak/result-service/clock"
 	"github.com/andrewshostak/result-service/config"
 	loggerinternal "github.com/andrewshostak/result-service/logger"
+	"github.com/andrewshostak/result-service/metrics"
 	"github.com/andrewshostak/result-service/repository"
 	"github.com/andrewshostak/result-service/service"
 	"github.com/spf13/cobra"
@@ -22,6 +28,9 @@ func main() {
 	}
 
 	rootCmd.Flags().Uint("season", 0, "query param in leagues endpoint of football-api")
+	rootCmd.Flags().Bool("dry-run", false, "print aliases that would be created and conflicts without writing to the database")
+	rootCmd.Flags().String("format", formatTable, "report format: table or json")
+	rootCmd.Flags().Uint("workers", 3, "number of leagues processed concurrently")
 
 	if err := rootCmd.Execute(); err != nil {
 		panic(err)
@@ -38,24 +47,79 @@ func run(cmd *cobra.Command, _ []string) {
 		panic(errors.New("season flag cannot be empty"))
 	}
 
-	cfg := config.Parse()
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		panic(err)
+	}
 
-	logger := loggerinternal.SetupLogger()
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		panic(err)
+	}
 
-	httpClient := http.Client{}
+	if format != formatTable && format != formatJSON {
+		panic(fmt.Errorf("format flag must be %s or %s", formatTable, formatJSON))
+	}
 
-	db := repository.EstablishDatabaseConnection(cfg)
+	workers, err := cmd.Flags().GetUint("workers")
+	if err != nil {
+		panic(err)
+	}
 
-	aliasRepository := repository.NewAliasRepository(db)
+	if workers == 0 {
+		panic(errors.New("workers flag must be greater than zero"))
+	}
 
-	footballAPIClient := client.NewFootballAPIClient(&httpClient, logger, cfg.ExternalAPI.FootballAPIBaseURL, cfg.ExternalAPI.RapidAPIKey)
+	cfg := config.Parse()
 
-	backfillAliasesService := service.NewBackfillAliasesService(aliasRepository, footballAPIClient, logger)
+	logger, err := loggerinternal.SetupLogger(cfg.Log)
+	if err != nil {
+		panic(err)
+	}
 
-	ctx := context.Background()
+	db := repository.EstablishDatabaseConnection(cfg)
 
-	err = backfillAliasesService.Backfill(ctx, season)
-	if err != nil {
+	aliasRepository := repository.NewAliasRepository(db)
+
+	realClock := clock.New()
+	quotaTracker := client.NewQuotaTracker(realClock, client.QuotaPolicy{
+		Reserve:            cfg.ExternalAPI.FootballAPIQuotaReserve,
+		LowPriorityReserve: cfg.ExternalAPI.FootballAPIQuotaLowPriorityReserve,
+	})
+	footballAPIHTTPClient := http.Client{Timeout: cfg.ExternalAPI.FootballAPITimeout}
+	footballAPIClient := client.NewFootballAPIClient(
+		&footballAPIHTTPClient,
+		logger,
+		cfg.ExternalAPI.FootballAPIBaseURL,
+		cfg.ExternalAPI.RapidAPIKey,
+		client.RetryPolicy{
+			MaxRetries: cfg.ExternalAPI.FootballAPIMaxRetries,
+			BaseDelay:  cfg.ExternalAPI.FootballAPIRetryBaseDelay,
+			MaxDelay:   cfg.ExternalAPI.FootballAPIRetryMaxDelay,
+		},
+		client.NewCircuitBreaker(realClock, cfg.ExternalAPI.FootballAPIBreakerThreshold, cfg.ExternalAPI.FootballAPIBreakerOpenDuration),
+		quotaTracker,
+		metrics.New(),
+		realClock,
+	)
+
+	backfillAliasesService := service.NewBackfillAliasesService(aliasRepository, footballAPIClient, logger, realClock, workers)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	report, errBackfill := backfillAliasesService.Backfill(ctx, season, dryRun)
+	if report == nil {
+		panic(errBackfill)
+	}
+
+	if err := printReport(os.Stdout, *report, format); err != nil {
 		panic(err)
 	}
+
+	if errBackfill != nil {
+		logger.Error().Err(errBackfill).Msg("aliases backfill failed")
+		stop()
+		os.Exit(1)
+	}
 }
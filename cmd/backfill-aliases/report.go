@@ -0,0 +1,191 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/andrewshostak/result-service/service"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+type report struct {
+	Season  uint           `json:"season"`
+	DryRun  bool           `json:"dry_run"`
+	Summary summary        `json:"summary"`
+	Leagues []leagueReport `json:"leagues"`
+}
+
+type summary struct {
+	Created       int `json:"created"`
+	Renamed       int `json:"renamed"`
+	Existing      int `json:"existing"`
+	Conflicts     int `json:"conflicts"`
+	Failed        int `json:"failed"`
+	FailedLeagues int `json:"failed_leagues"`
+}
+
+type leagueReport struct {
+	League    string     `json:"league"`
+	Country   string     `json:"country"`
+	Created   []team     `json:"created"`
+	Renamed   []rename   `json:"renamed"`
+	Existing  []team     `json:"existing"`
+	Conflicts []conflict `json:"conflicts"`
+	Failed    []team     `json:"failed"`
+	Error     string     `json:"error,omitempty"`
+}
+
+type team struct {
+	FootballAPITeamID uint   `json:"football_api_team_id"`
+	Name              string `json:"name"`
+}
+
+type rename struct {
+	FootballAPITeamID uint   `json:"football_api_team_id"`
+	Name              string `json:"name"`
+	ExistingAlias     string `json:"existing_alias"`
+	ExistingTeamID    uint   `json:"existing_team_id"`
+}
+
+type conflict struct {
+	FootballAPITeamID         uint   `json:"football_api_team_id"`
+	Name                      string `json:"name"`
+	ExistingAlias             string `json:"existing_alias"`
+	ExistingTeamID            uint   `json:"existing_team_id"`
+	ExistingFootballAPITeamID uint   `json:"existing_football_api_team_id,omitempty"`
+	Reason                    string `json:"reason"`
+}
+
+func printReport(w io.Writer, backfillReport service.BackfillReport, format string) error {
+	r := toReport(backfillReport)
+
+	switch format {
+	case formatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r)
+	case formatTable:
+		return printTable(w, r)
+	default:
+		return fmt.Errorf("unknown report format %s", format)
+	}
+}
+
+func printTable(w io.Writer, r report) error {
+	createdAction, renamedAction := "created", "renamed"
+	if r.DryRun {
+		createdAction, renamedAction = "to create", "to rename"
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "LEAGUE\tCOUNTRY\tACTION\tNAME\tFOOTBALL API TEAM ID\tEXISTING ALIAS\tEXISTING TEAM ID\tEXISTING FOOTBALL API TEAM ID\tREASON")
+
+	for _, l := range r.Leagues {
+		for _, t := range l.Created {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t\t\t\t\n", l.League, l.Country, createdAction, t.Name, t.FootballAPITeamID)
+		}
+
+		for _, rn := range l.Renamed {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%s\t%d\t%d\t\n", l.League, l.Country, renamedAction, rn.Name, rn.FootballAPITeamID, rn.ExistingAlias, rn.ExistingTeamID, rn.FootballAPITeamID)
+		}
+
+		for _, c := range l.Conflicts {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%s\t%d\t%d\t%s\n", l.League, l.Country, "conflict", c.Name, c.FootballAPITeamID, c.ExistingAlias, c.ExistingTeamID, c.ExistingFootballAPITeamID, c.Reason)
+		}
+
+		for _, t := range l.Failed {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t\t\t\t\n", l.League, l.Country, "failed", t.Name, t.FootballAPITeamID)
+		}
+
+		if l.Error != "" && len(l.Failed) == 0 {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t\t\t\t\t\t%s\n", l.League, l.Country, "league failed", l.Error)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write report table: %w", err)
+	}
+
+	_, err := fmt.Fprintf(w, "\nseason: %d, dry run: %t, %s: %d, %s: %d, existing: %d, conflicts: %d, failed: %d, failed leagues: %d\n",
+		r.Season, r.DryRun, createdAction, r.Summary.Created, renamedAction, r.Summary.Renamed, r.Summary.Existing, r.Summary.Conflicts, r.Summary.Failed, r.Summary.FailedLeagues)
+
+	return err
+}
+
+func toReport(r service.BackfillReport) report {
+	leagues := make([]leagueReport, 0, len(r.Leagues))
+	s := summary{}
+
+	for _, l := range r.Leagues {
+		conflicts := make([]conflict, 0, len(l.Conflicts))
+		for _, c := range l.Conflicts {
+			conflicts = append(conflicts, conflict{
+				FootballAPITeamID:         c.Team.ID,
+				Name:                      c.Team.Name,
+				ExistingAlias:             c.ExistingAlias,
+				ExistingTeamID:            c.ExistingTeamID,
+				ExistingFootballAPITeamID: c.ExistingFootballAPITeamID,
+				Reason:                    c.Reason,
+			})
+		}
+
+		renames := make([]rename, 0, len(l.Renamed))
+		for _, rn := range l.Renamed {
+			renames = append(renames, rename{
+				FootballAPITeamID: rn.Team.ID,
+				Name:              rn.Team.Name,
+				ExistingAlias:     rn.ExistingAlias,
+				ExistingTeamID:    rn.ExistingTeamID,
+			})
+		}
+
+		var leagueError string
+		if l.Err != nil {
+			leagueError = l.Err.Error()
+			s.FailedLeagues++
+		}
+
+		leagues = append(leagues, leagueReport{
+			League:    l.League.League.Name,
+			Country:   l.League.Country.Name,
+			Created:   toTeams(l.Created),
+			Renamed:   renames,
+			Existing:  toTeams(l.Existing),
+			Conflicts: conflicts,
+			Failed:    toTeams(l.Failed),
+			Error:     leagueError,
+		})
+
+		s.Created += len(l.Created)
+		s.Renamed += len(l.Renamed)
+		s.Existing += len(l.Existing)
+		s.Conflicts += len(l.Conflicts)
+		s.Failed += len(l.Failed)
+	}
+
+	sort.Slice(leagues, func(i, j int) bool {
+		if leagues[i].Country != leagues[j].Country {
+			return leagues[i].Country < leagues[j].Country
+		}
+
+		return leagues[i].League < leagues[j].League
+	})
+
+	return report{Season: r.Season, DryRun: r.DryRun, Summary: s, Leagues: leagues}
+}
+
+func toTeams(t []service.TeamExternal) []team {
+	teams := make([]team, 0, len(t))
+	for i := range t {
+		teams = append(teams, team{FootballAPITeamID: t[i].ID, Name: t[i].Name})
+	}
+
+	return teams
+}
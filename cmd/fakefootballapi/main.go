@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/andrewshostak/result-service/config"
+	"github.com/andrewshostak/result-service/fakefootballapi"
+	loggerinternal "github.com/andrewshostak/result-service/logger"
+	"github.com/spf13/cobra"
+)
+
+func main() {
+	rootCmd := &cobra.Command{
+		Use:   "run",
+		Short: "Runs football-api simulator for local development",
+		Run:   run,
+	}
+
+	rootCmd.Flags().String("port", "8081", "port to listen on")
+	rootCmd.Flags().String("scenario", "", "path to a json scenario file")
+	rootCmd.Flags().Uint("daily-limit", 0, "daily requests quota, zero disables quota headers")
+
+	if err := rootCmd.Execute(); err != nil {
+		panic(err)
+	}
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	port, err := cmd.Flags().GetString("port")
+	if err != nil {
+		panic(err)
+	}
+
+	scenarioPath, err := cmd.Flags().GetString("scenario")
+	if err != nil {
+		panic(err)
+	}
+
+	dailyLimit, err := cmd.Flags().GetUint("daily-limit")
+	if err != nil {
+		panic(err)
+	}
+
+	logger, err := loggerinternal.SetupLogger(config.ParseLog())
+	if err != nil {
+		panic(err)
+	}
+
+	store := fakefootballapi.NewStore(time.Now)
+	if scenarioPath != "" {
+		scenario, err := readScenario(scenarioPath)
+		if err != nil {
+			panic(err)
+		}
+
+		if err := store.Load(*scenario); err != nil {
+			panic(fmt.Errorf("failed to load scenario: %w", err))
+		}
+
+		logger.Info().Str("scenario", scenarioPath).Int("fixtures", len(scenario.Fixtures)).Msg("scenario loaded")
+	}
+
+	server := fakefootballapi.NewServer(store, dailyLimit)
+
+	logger.Info().Str("port", port).Msg("football-api simulator is listening")
+	if err := server.Router().Run(fmt.Sprintf(":%s", port)); err != nil {
+		panic(err)
+	}
+}
+
+func readScenario(path string) (*fakefootballapi.Scenario, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open scenario file: %w", err)
+	}
+	defer file.Close()
+
+	var scenario fakefootballapi.Scenario
+	if err := json.NewDecoder(file).Decode(&scenario); err != nil {
+		return nil, fmt.Errorf("failed to decode scenario file: %w", err)
+	}
+
+	return &scenario, nil
+}
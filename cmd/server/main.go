@@ -2,21 +2,19 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"os/signal"
+	"syscall"
 
-	"github.com/andrewshostak/result-service/client"
+	"github.com/andrewshostak/result-service/app"
+	"github.com/andrewshostak/result-service/clock"
 	"github.com/andrewshostak/result-service/config"
-	"github.com/andrewshostak/result-service/handler"
-	"github.com/andrewshostak/result-service/initializer"
 	loggerinternal "github.com/andrewshostak/result-service/logger"
-	"github.com/andrewshostak/result-service/middleware"
 	"github.com/andrewshostak/result-service/repository"
-	"github.com/andrewshostak/result-service/scheduler"
-	"github.com/andrewshostak/result-service/service"
-	"github.com/gin-gonic/gin"
+	"github.com/andrewshostak/result-service/tracing"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
-	"github.com/procyon-projects/chrono"
 	"github.com/spf13/cobra"
 )
 
@@ -35,63 +33,69 @@ func main() {
 func startServer(_ *cobra.Command, _ []string) {
 	cfg := config.Parse()
 
-	logger := loggerinternal.SetupLogger()
+	logger, err := loggerinternal.SetupLogger(cfg.Log)
+	if err != nil {
+		panic(err)
+	}
 
-	r := gin.Default()
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		panic(err)
+	}
 
 	db := repository.EstablishDatabaseConnection(cfg)
-	httpClient := http.Client{}
-	chronoTaskScheduler := chrono.NewDefaultTaskScheduler()
-
-	r.GET("/_ah/start", func(c *gin.Context) {
-		c.Status(http.StatusOK)
-	})
-
-	r.Use(middleware.Authorization(cfg.App.HashedAPIKeys, cfg.App.SecretKey))
-
-	v1 := r.Group("/v1")
-
-	footballAPIClient := client.NewFootballAPIClient(&httpClient, logger, cfg.ExternalAPI.FootballAPIBaseURL, cfg.ExternalAPI.RapidAPIKey)
-	notifierClient := client.NewNotifierClient(&httpClient, logger)
-
-	aliasRepository := repository.NewAliasRepository(db)
-	matchRepository := repository.NewMatchRepository(db)
-	footballAPIFixtureRepository := repository.NewFootballAPIFixtureRepository(db)
-	subscriptionRepository := repository.NewSubscriptionRepository(db)
-
-	taskScheduler := scheduler.NewTaskScheduler(chronoTaskScheduler)
-
-	matchService := service.NewMatchService(
-		aliasRepository,
-		matchRepository,
-		footballAPIFixtureRepository,
-		footballAPIClient,
-		taskScheduler,
-		logger,
-		cfg.Result.PollingMaxRetries,
-		cfg.Result.PollingInterval,
-		cfg.Result.PollingFirstAttemptDelay,
-	)
-	subscriptionService := service.NewSubscriptionService(subscriptionRepository, matchRepository, aliasRepository, taskScheduler, logger)
-	notifierService := service.NewNotifierService(subscriptionRepository, notifierClient, logger)
-	aliasService := service.NewAliasService(aliasRepository, logger)
-
-	matchHandler := handler.NewMatchHandler(matchService)
-	subscriptionHandler := handler.NewSubscriptionHandler(subscriptionService)
-	aliasHandler := handler.NewAliasHandler(aliasService)
-	v1.POST("/matches", matchHandler.Create)
-	v1.POST("/subscriptions", subscriptionHandler.Create)
-	v1.DELETE("/subscriptions", subscriptionHandler.Delete)
-	v1.GET("/aliases", aliasHandler.Search)
-
-	ctx := context.Background()
-	matchResultScheduleInitializer := initializer.NewMatchResultScheduleInitializer(matchService, logger)
-	if err := matchResultScheduleInitializer.ReSchedule(ctx); err != nil {
+
+	a, err := app.New(cfg, db, logger, clock.New())
+	if err != nil {
 		panic(err)
 	}
 
-	notifierInitializer := initializer.NewNotifierInitializer(notifierService)
-	notifierInitializer.Start()
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := a.Start(ctx); err != nil {
+		panic(err)
+	}
+
+	server := &http.Server{Addr: fmt.Sprintf(":%s", cfg.App.Port), Handler: a.Router}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		logger.Info().Str("addr", server.Addr).Msg("server started")
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		logger.Info().Msg("shutdown signal received")
+	case err := <-serverErr:
+		logger.Error().Err(err).Msg("server failed")
+		stop()
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.App.ShutdownTimeout)
+	defer cancel()
+
+	logger.Info().Dur("timeout", cfg.App.ShutdownTimeout).Msg("shutting down server")
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error().Err(err).Msg("failed to wait for in-flight requests")
+	}
+
+	if err := a.Shutdown(shutdownCtx); err != nil {
+		logger.Error().Err(err).Msg("failed to wait for background jobs")
+	}
+
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error().Err(err).Msg("failed to shutdown tracing")
+	}
+
+	if sqlDB, err := db.DB(); err == nil {
+		if err := sqlDB.Close(); err != nil {
+			logger.Error().Err(err).Msg("failed to close database connections")
+		}
+	}
 
-	_ = r.Run(fmt.Sprintf(":%s", cfg.App.Port))
+	logger.Info().Msg("server stopped")
 }
@@ -7,27 +7,107 @@ import (
 )
 
 type Config struct {
-	App         App
-	ExternalAPI ExternalAPI
-	Result      ResultPolling
-	PG          PG
+	App             App
+	ExternalAPI     ExternalAPI
+	Result          ResultPolling
+	BackfillAliases BackfillAliases
+	PG              PG
+	Tracing         Tracing
+	Log             Log
+	Notifier        Notifier
 }
 
 type App struct {
 	Port          string   `env:"PORT" envDefault:"8080"`
 	HashedAPIKeys []string `env:"HASHED_API_KEYS" envSeparator:","`
 	SecretKey     string   `env:"SECRET_KEY,required"`
+	// ShutdownTimeout limits waiting for in-flight requests, polling tasks and notifications on shutdown
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"30s"`
 }
 
 type ExternalAPI struct {
 	RapidAPIKey        string `env:"RAPID_API_KEY,required"`
 	FootballAPIBaseURL string `env:"FOOTBALL_API_BASE_URL" envDefault:"https://api-football-v1.p.rapidapi.com"`
+	// ResultFeedURL enables a generic JSON feed as a fallback result provider
+	ResultFeedURL string `env:"RESULT_FEED_URL"`
+	ResultFeedKey string `env:"RESULT_FEED_KEY"`
+
+	FootballAPITimeout        time.Duration `env:"FOOTBALL_API_TIMEOUT" envDefault:"10s"`
+	FootballAPIMaxRetries     uint          `env:"FOOTBALL_API_MAX_RETRIES" envDefault:"2"`
+	FootballAPIRetryBaseDelay time.Duration `env:"FOOTBALL_API_RETRY_BASE_DELAY" envDefault:"500ms"`
+	FootballAPIRetryMaxDelay  time.Duration `env:"FOOTBALL_API_RETRY_MAX_DELAY" envDefault:"5s"`
+	// FootballAPIBreakerThreshold is a number of consecutive failed requests which opens the circuit
+	FootballAPIBreakerThreshold    uint          `env:"FOOTBALL_API_BREAKER_THRESHOLD" envDefault:"5"`
+	FootballAPIBreakerOpenDuration time.Duration `env:"FOOTBALL_API_BREAKER_OPEN_DURATION" envDefault:"1m"`
+	// FootballAPIBatchWindow is a time to collect fixture requests to send them in one request
+	FootballAPIBatchWindow time.Duration `env:"FOOTBALL_API_BATCH_WINDOW" envDefault:"2s"`
+	// FootballAPIQuotaReserve is a daily quota kept for result polling
+	FootballAPIQuotaReserve uint `env:"FOOTBALL_API_QUOTA_RESERVE" envDefault:"20"`
+	// FootballAPIQuotaLowPriorityReserve is a daily quota which aliases backfill can't use
+	FootballAPIQuotaLowPriorityReserve uint `env:"FOOTBALL_API_QUOTA_LOW_PRIORITY_RESERVE" envDefault:"50"`
 }
 
 type ResultPolling struct {
 	PollingMaxRetries        uint          `env:"POLLING_MAX_RETRIES" envDefault:"5"`
 	PollingInterval          time.Duration `env:"POLLING_INTERVAL" envDefault:"15m"`
 	PollingFirstAttemptDelay time.Duration `env:"POLLING_FIRST_ATTEMPT_DELAY" envDefault:"115m"`
+	// PollingMaxDuration limits attempts which are not counted in the retries limit (e.g. when football-api quota is exhausted).
+	// It is measured from the match start
+	PollingMaxDuration time.Duration `env:"POLLING_MAX_DURATION" envDefault:"24h"`
+	// KnockoutFirstAttemptDelay is used for knockout rounds, which may have extra time and penalties
+	KnockoutFirstAttemptDelay time.Duration `env:"POLLING_KNOCKOUT_FIRST_ATTEMPT_DELAY" envDefault:"145m"`
+	// LivePollingInterval is used near the end of the second half and the extra time
+	LivePollingInterval time.Duration `env:"POLLING_LIVE_INTERVAL" envDefault:"2m"`
+	HalfTimeInterval    time.Duration `env:"POLLING_HALF_TIME_INTERVAL" envDefault:"10m"`
+	// LiveMaxDuration limits attempts which are not counted while the match is in progress. It is measured from the match start
+	LiveMaxDuration time.Duration `env:"POLLING_LIVE_MAX_DURATION" envDefault:"4h"`
+	// VerificationMode is one of off, provider or delayed
+	VerificationMode  string        `env:"RESULT_VERIFICATION_MODE" envDefault:"off"`
+	VerificationDelay time.Duration `env:"RESULT_VERIFICATION_DELAY" envDefault:"10m"`
+}
+
+type BackfillAliases struct {
+	Enabled bool `env:"BACKFILL_ALIASES_ENABLED" envDefault:"false"`
+	// Schedule is a cron expression with seconds field evaluated in UTC
+	Schedule string `env:"BACKFILL_ALIASES_SCHEDULE" envDefault:"0 0 4 * * MON"`
+	Workers  uint   `env:"BACKFILL_ALIASES_WORKERS" envDefault:"3"`
+}
+
+type Notifier struct {
+	// Interval is the time between runs which look for subscriptions to notify
+	Interval time.Duration `env:"NOTIFIER_INTERVAL" envDefault:"1m"`
+	// Workers is a number of notifications sent in parallel
+	Workers uint `env:"NOTIFIER_WORKERS" envDefault:"10"`
+	// PerHostConcurrency is a number of notifications sent in parallel to the same host
+	PerHostConcurrency uint `env:"NOTIFIER_PER_HOST_CONCURRENCY" envDefault:"2"`
+	// Events is local (results of matches polled by the instance trigger its notifier) or postgres
+	// (LISTEN/NOTIFY triggers notifiers of all instances)
+	Events string `env:"NOTIFIER_EVENTS" envDefault:"local"`
+	// DeliveryTimeout limits a single notification request including reading the response
+	DeliveryTimeout time.Duration `env:"NOTIFIER_DELIVERY_TIMEOUT" envDefault:"10s"`
+}
+
+type Tracing struct {
+	// Exporter is one of none, stdout (for local runs) or otlp
+	Exporter string `env:"TRACING_EXPORTER" envDefault:"none"`
+	// OTLPEndpoint is host:port of OTLP/HTTP collector, OTEL_EXPORTER_OTLP_* variables are used when it is empty
+	OTLPEndpoint string  `env:"TRACING_OTLP_ENDPOINT"`
+	OTLPInsecure bool    `env:"TRACING_OTLP_INSECURE" envDefault:"false"`
+	SampleRatio  float64 `env:"TRACING_SAMPLE_RATIO" envDefault:"1"`
+}
+
+type Log struct {
+	// Level is one of debug, info, warn or error
+	Level string `env:"LOG_LEVEL" envDefault:"info"`
+	// Format is json or console (human-readable, for local runs)
+	Format string `env:"LOG_FORMAT" envDefault:"json"`
+	// File enables writing logs to the file in addition to stderr. The file is rotated by size
+	File           string `env:"LOG_FILE"`
+	FileMaxSizeMB  int    `env:"LOG_FILE_MAX_SIZE_MB" envDefault:"100"`
+	FileMaxBackups int    `env:"LOG_FILE_MAX_BACKUPS" envDefault:"5"`
+	FileMaxAgeDays int    `env:"LOG_FILE_MAX_AGE_DAYS" envDefault:"30"`
+	// ComponentLevels overrides the level of components, e.g. match:debug,football-api:warn
+	ComponentLevels map[string]string `env:"LOG_COMPONENT_LEVELS"`
 }
 
 type PG struct {
@@ -36,6 +116,8 @@ type PG struct {
 	Password string `env:"PG_PASSWORD,required"`
 	Port     string `env:"PG_PORT" envDefault:"5432"`
 	Database string `env:"PG_DATABASE" envDefault:"postgres"`
+	// MigrationsSource is a golang-migrate source url of the migrations applied on start
+	MigrationsSource string `env:"PG_MIGRATIONS_SOURCE" envDefault:"file://./migrations"`
 }
 
 func Parse() Config {
@@ -46,3 +128,13 @@ func Parse() Config {
 
 	return config
 }
+
+// ParseLog parses only logging config, it is used by tools which don't need the rest of the config.
+func ParseLog() Log {
+	config := Log{}
+	if err := env.Parse(&config); err != nil {
+		panic(err)
+	}
+
+	return config
+}
@@ -6,6 +6,12 @@ var (
 	ErrIncorrectFixtureStatus          = errors.New("incorrect fixture status")
 	ErrUnexpectedAPIFootballStatusCode = errors.New("unexpected status code received from api-football")
 	ErrUnexpectedNotifierStatusCode    = errors.New("unexpected status code received from notifier")
+	ErrUnexpectedResultFeedStatusCode  = errors.New("unexpected status code received from result feed")
+	ErrResultNotFound                  = errors.New("result is not found")
+	ErrCircuitOpen                     = errors.New("circuit breaker is open")
+	ErrQuotaExhausted                  = errors.New("football api quota is exhausted")
+	ErrShuttingDown                    = errors.New("service is shutting down")
+	ErrNotificationLeaseExpired        = errors.New("notification lease is expired")
 )
 
 type AliasNotFoundError struct {
@@ -63,3 +69,19 @@ type SubscriptionWrongStatusError struct {
 func (e SubscriptionWrongStatusError) Error() string {
 	return e.Message
 }
+
+type AliasImportConflictError struct {
+	Message string
+}
+
+func (e AliasImportConflictError) Error() string {
+	return e.Message
+}
+
+type AmbiguousFixtureError struct {
+	Message string
+}
+
+func (e AmbiguousFixtureError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,21 @@
+package events
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+)
+
+type Logger interface {
+	Error() *zerolog.Event
+	Info() *zerolog.Event
+	Warn() *zerolog.Event
+}
+
+// Conn is a dedicated database connection which receives notifications. *pgx.Conn implements it.
+type Conn interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	WaitForNotification(ctx context.Context) (*pgconn.Notification, error)
+	Close(ctx context.Context) error
+}
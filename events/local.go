@@ -0,0 +1,34 @@
+package events
+
+import (
+	"context"
+	"errors"
+)
+
+// bufferSize is a number of events kept until the notifier handles them. Events published to the full buffer are dropped,
+// subscribers of such matches are notified by the periodic sweep.
+const bufferSize = 100
+
+var errBufferFull = errors.New("match results buffer is full")
+
+// Local delivers match results to the notifier of the same instance.
+type Local struct {
+	results chan uint
+}
+
+func NewLocal() *Local {
+	return &Local{results: make(chan uint, bufferSize)}
+}
+
+func (l *Local) PublishMatchResult(_ context.Context, matchID uint) error {
+	select {
+	case l.results <- matchID:
+		return nil
+	default:
+		return errBufferFull
+	}
+}
+
+func (l *Local) MatchResults() <-chan uint {
+	return l.results
+}
@@ -0,0 +1,117 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+)
+
+const (
+	matchResultsChannel = "match_results"
+	reconnectDelay      = 5 * time.Second
+)
+
+// Postgres delivers match results to notifiers of all instances with LISTEN/NOTIFY. A notification is published
+// in the database the instances share, and each instance listens on a dedicated connection outside the pool.
+type Postgres struct {
+	db      *gorm.DB
+	connect Connect
+	logger  Logger
+	clock   clock.Clock
+	results chan uint
+}
+
+// Connect opens the connection to listen on.
+type Connect func(ctx context.Context) (Conn, error)
+
+// PgxConnect opens pgx connections outside the pool.
+func PgxConnect(connectionString string) Connect {
+	return func(ctx context.Context) (Conn, error) {
+		conn, err := pgx.Connect(ctx, connectionString)
+		if err != nil {
+			return nil, err
+		}
+
+		return conn, nil
+	}
+}
+
+func NewPostgres(db *gorm.DB, connect Connect, logger Logger, clock clock.Clock) *Postgres {
+	return &Postgres{db: db, connect: connect, logger: logger, clock: clock, results: make(chan uint, bufferSize)}
+}
+
+func (p *Postgres) PublishMatchResult(ctx context.Context, matchID uint) error {
+	result := p.db.WithContext(ctx).Exec("select pg_notify(?, ?)", matchResultsChannel, strconv.FormatUint(uint64(matchID), 10))
+	if result.Error != nil {
+		return fmt.Errorf("failed to notify %s channel: %w", matchResultsChannel, result.Error)
+	}
+
+	return nil
+}
+
+func (p *Postgres) MatchResults() <-chan uint {
+	return p.results
+}
+
+// Listen receives match results until ctx is cancelled. The connection is re-established after errors,
+// results published in the meantime are delivered by the periodic sweep.
+func (p *Postgres) Listen(ctx context.Context) {
+	for {
+		err := p.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		p.logger.Error().Err(err).Dur("reconnect_in", reconnectDelay).Msg("match results listener is disconnected")
+
+		reconnect := make(chan struct{})
+		timer := p.clock.AfterFunc(reconnectDelay, func() { close(reconnect) })
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-reconnect:
+		}
+	}
+}
+
+func (p *Postgres) listen(ctx context.Context) error {
+	conn, err := p.connect(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer func() {
+		_ = conn.Close(context.Background())
+	}()
+
+	if _, err := conn.Exec(ctx, "listen "+matchResultsChannel); err != nil {
+		return fmt.Errorf("failed to listen %s channel: %w", matchResultsChannel, err)
+	}
+
+	p.logger.Info().Str("channel", matchResultsChannel).Msg("listening to match results")
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to wait for notification: %w", err)
+		}
+
+		matchID, err := strconv.ParseUint(notification.Payload, 10, 64)
+		if err != nil {
+			p.logger.Error().Err(err).Str("payload", notification.Payload).Msg("unexpected match results notification payload")
+			continue
+		}
+
+		select {
+		case p.results <- uint(matchID):
+		default:
+			p.logger.Warn().Uint64("match_id", matchID).Err(errBufferFull).Msg("match result event is dropped")
+		}
+	}
+}
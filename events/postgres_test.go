@@ -0,0 +1,145 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/andrewshostak/result-service/events"
+	"github.com/andrewshostak/result-service/events/mocks"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestPostgres_PublishMatchResult(t *testing.T) {
+	sqlDB, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	require.NoError(t, err)
+	defer func() { _ = sqlDB.Close() }()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec(regexp.QuoteMeta(`select pg_notify($1, $2)`)).WithArgs("match_results", "7").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	l := zerolog.Nop()
+	p := events.NewPostgres(db, nil, &l, clock.NewFake(time.Now()))
+
+	assert.NoError(t, p.PublishMatchResult(context.Background(), 7))
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPostgres_Listen(t *testing.T) {
+	now := time.Date(2024, time.January, 20, 17, 0, 0, 0, time.UTC)
+	l := zerolog.Nop()
+
+	// listening connection returns the payloads and then waits until the listener is stopped
+	listening := func(t *testing.T, payloads ...string) *mocks.Conn {
+		conn := mocks.NewConn(t)
+		conn.On("Exec", mock.Anything, "listen match_results").Return(pgconn.CommandTag{}, nil).Once()
+		for _, payload := range payloads {
+			conn.On("WaitForNotification", mock.Anything).Return(&pgconn.Notification{Channel: "match_results", Payload: payload}, nil).Once()
+		}
+		conn.On("WaitForNotification", mock.Anything).Return(nil, context.Canceled).Once().
+			Run(func(args mock.Arguments) { <-args.Get(0).(context.Context).Done() })
+		conn.On("Close", mock.Anything).Return(nil).Once()
+
+		return conn
+	}
+
+	receive := func(t *testing.T, p *events.Postgres) uint {
+		t.Helper()
+
+		select {
+		case matchID := <-p.MatchResults():
+			return matchID
+		case <-time.After(time.Second):
+			t.Fatal("match result is not received")
+			return 0
+		}
+	}
+
+	listen := func(ctx context.Context, p *events.Postgres) <-chan struct{} {
+		done := make(chan struct{})
+		go func() {
+			p.Listen(ctx)
+			close(done)
+		}()
+
+		return done
+	}
+
+	waitForStop := func(t *testing.T, done <-chan struct{}) {
+		t.Helper()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("listener is not stopped")
+		}
+	}
+
+	t.Run("it should deliver match results and skip unexpected payloads", func(t *testing.T) {
+		conn := listening(t, "7", "not a match id", "9")
+		connect := func(context.Context) (events.Conn, error) { return conn, nil }
+
+		p := events.NewPostgres(nil, connect, &l, clock.NewFake(now))
+		ctx, cancel := context.WithCancel(context.Background())
+		done := listen(ctx, p)
+
+		assert.Equal(t, uint(7), receive(t, p))
+		assert.Equal(t, uint(9), receive(t, p))
+
+		cancel()
+		waitForStop(t, done)
+	})
+
+	t.Run("it should reconnect after the delay when the connection fails", func(t *testing.T) {
+		conn := listening(t, "7")
+		// connect is called by the listener goroutine only
+		refused := false
+		connect := func(context.Context) (events.Conn, error) {
+			if !refused {
+				refused = true
+				return nil, errors.New("connection refused")
+			}
+
+			return conn, nil
+		}
+
+		fakeClock := clock.NewFake(now)
+		p := events.NewPostgres(nil, connect, &l, fakeClock)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := listen(ctx, p)
+
+		require.Eventually(t, func() bool { return fakeClock.Timers() == 1 }, time.Second, time.Millisecond, "reconnection is not scheduled")
+		fakeClock.Advance(5 * time.Second)
+		assert.Equal(t, uint(7), receive(t, p))
+
+		cancel()
+		waitForStop(t, done)
+	})
+
+	t.Run("it should stop waiting for reconnection when the listener is stopped", func(t *testing.T) {
+		connect := func(context.Context) (events.Conn, error) { return nil, errors.New("connection refused") }
+
+		fakeClock := clock.NewFake(now)
+		p := events.NewPostgres(nil, connect, &l, fakeClock)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := listen(ctx, p)
+
+		require.Eventually(t, func() bool { return fakeClock.Timers() == 1 }, time.Second, time.Millisecond, "reconnection is not scheduled")
+		cancel()
+		waitForStop(t, done)
+		assert.Equal(t, 0, fakeClock.Timers())
+	})
+}
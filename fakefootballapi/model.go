@@ -0,0 +1,81 @@
+package fakefootballapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Scenario describes leagues, teams and fixtures served by the simulator.
+type Scenario struct {
+	Leagues  []ScenarioLeague  `json:"leagues"`
+	Fixtures []ScenarioFixture `json:"fixtures"`
+}
+
+type ScenarioLeague struct {
+	ID      uint           `json:"id"`
+	Name    string         `json:"name"`
+	Country string         `json:"country"`
+	Season  uint           `json:"season"`
+	Teams   []ScenarioTeam `json:"teams"`
+}
+
+type ScenarioTeam struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// ScenarioFixture is a fixture with its timeline. Kick-off is set either by Date or by StartsIn,
+// which is relative to the time the scenario is loaded.
+type ScenarioFixture struct {
+	ID         uint           `json:"id"`
+	LeagueID   uint           `json:"league_id"`
+	Season     uint           `json:"season"`
+	Round      string         `json:"round"`
+	HomeTeamID uint           `json:"home_team_id"`
+	AwayTeamID uint           `json:"away_team_id"`
+	Date       *time.Time     `json:"date"`
+	StartsIn   *Duration      `json:"starts_in"`
+	Timeline   []TimelineStep `json:"timeline"`
+}
+
+// TimelineStep changes the fixture state After the kick-off. Elapsed minutes are counted from the step
+// for statuses of a match in progress, when it is not set, the usual minute of the period start is used.
+type TimelineStep struct {
+	After   Duration `json:"after"`
+	Status  string   `json:"status"`
+	Elapsed *uint    `json:"elapsed"`
+	Home    uint     `json:"home"`
+	Away    uint     `json:"away"`
+}
+
+// FixtureState is a state of the fixture set by admin endpoint. It overrides the timeline.
+type FixtureState struct {
+	Status  string `json:"status" binding:"required"`
+	Elapsed *uint  `json:"elapsed"`
+	Home    uint   `json:"home"`
+	Away    uint   `json:"away"`
+}
+
+// Duration is time.Duration decoded from a string like "1h30m".
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var value string
+	if err := json.Unmarshal(b, &value); err != nil {
+		return fmt.Errorf("duration must be a string: %w", err)
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("failed to parse duration %s: %w", value, err)
+	}
+
+	*d = Duration(parsed)
+
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
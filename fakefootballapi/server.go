@@ -0,0 +1,226 @@
+package fakefootballapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	dailyLimitHeader     = "X-RateLimit-Requests-Limit"
+	dailyRemainingHeader = "X-RateLimit-Requests-Remaining"
+)
+
+// Server implements the subset of football-api endpoints used by result-service and admin endpoints
+// to change the scenario while the server is running.
+type Server struct {
+	store      *Store
+	dailyLimit uint
+
+	mutex    sync.Mutex
+	day      string
+	requests uint
+}
+
+// NewServer returns the server. When dailyLimit is not zero, quota headers are returned
+// and requests over the limit are rejected with 429 status.
+func NewServer(store *Store, dailyLimit uint) *Server {
+	return &Server{store: store, dailyLimit: dailyLimit}
+}
+
+func (s *Server) Router() *gin.Engine {
+	r := gin.New()
+	r.Use(gin.Recovery())
+
+	// status requests are not counted in the quota
+	r.GET("/v3/status", s.status)
+
+	v3 := r.Group("/v3", s.quota)
+	v3.GET("/fixtures", s.fixtures)
+	v3.GET("/leagues", s.leagues)
+	v3.GET("/teams", s.teams)
+
+	admin := r.Group("/admin")
+	admin.PUT("/scenario", s.loadScenario)
+	admin.POST("/fixtures", s.addFixture)
+	admin.PUT("/fixtures/:id/state", s.setFixtureState)
+
+	return r
+}
+
+func (s *Server) fixtures(c *gin.Context) {
+	search := FixtureSearch{Date: c.Query("date")}
+
+	ids := c.Query("ids")
+	if id := c.Query("id"); id != "" {
+		ids = id
+	}
+
+	if ids != "" {
+		for _, value := range strings.Split(ids, "-") {
+			id, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "ids must be numbers separated by -"})
+
+				return
+			}
+
+			search.IDs = append(search.IDs, uint(id))
+		}
+	}
+
+	var ok bool
+	if search.TeamID, ok = uintQuery(c, "team"); !ok {
+		return
+	}
+
+	if search.Season, ok = uintQuery(c, "season"); !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, response(s.store.Fixtures(search)))
+}
+
+func (s *Server) status(c *gin.Context) {
+	s.mutex.Lock()
+	requests := s.requests
+	s.mutex.Unlock()
+
+	c.JSON(http.StatusOK, gin.H{"errors": []string{}, "response": gin.H{
+		"account":  gin.H{"firstname": "fake", "lastname": "football-api"},
+		"requests": gin.H{"current": requests, "limit_day": s.dailyLimit},
+	}})
+}
+
+func (s *Server) leagues(c *gin.Context) {
+	season, ok := uintQuery(c, "season")
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, response(s.store.Leagues(season)))
+}
+
+func (s *Server) teams(c *gin.Context) {
+	league, ok := uintQuery(c, "league")
+	if !ok {
+		return
+	}
+
+	season, ok := uintQuery(c, "season")
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, response(s.store.Teams(league, season)))
+}
+
+func (s *Server) loadScenario(c *gin.Context) {
+	var scenario Scenario
+	if err := c.ShouldBindJSON(&scenario); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	if err := s.store.Load(scenario); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Server) addFixture(c *gin.Context) {
+	var fixture ScenarioFixture
+	if err := c.ShouldBindJSON(&fixture); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	if err := s.store.AddFixture(fixture); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Server) setFixtureState(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id must be a number"})
+
+		return
+	}
+
+	var state FixtureState
+	if err := c.ShouldBindJSON(&state); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	if err := s.store.SetState(uint(id), state); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// quota counts requests per UTC day the same way RapidAPI does.
+func (s *Server) quota(c *gin.Context) {
+	if s.dailyLimit == 0 {
+		return
+	}
+
+	s.mutex.Lock()
+	day := s.store.now().UTC().Format(time.DateOnly)
+	if s.day != day {
+		s.day = day
+		s.requests = 0
+	}
+
+	exceeded := s.requests >= s.dailyLimit
+	if !exceeded {
+		s.requests++
+	}
+	remaining := s.dailyLimit - s.requests
+	s.mutex.Unlock()
+
+	c.Header(dailyLimitHeader, strconv.Itoa(int(s.dailyLimit)))
+	c.Header(dailyRemainingHeader, strconv.Itoa(int(remaining)))
+
+	if exceeded {
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"message": "You have exceeded the DAILY quota for Requests on your current plan"})
+	}
+}
+
+func uintQuery(c *gin.Context, key string) (uint, bool) {
+	value := c.Query(key)
+	if value == "" {
+		return 0, true
+	}
+
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": key + " must be a number"})
+
+		return 0, false
+	}
+
+	return uint(parsed), true
+}
+
+func response[T any](items []T) gin.H {
+	return gin.H{"errors": []string{}, "results": len(items), "response": items}
+}
@@ -0,0 +1,75 @@
+package fakefootballapi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andrewshostak/result-service/fakefootballapi"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_Quota(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	startsAt := time.Date(2024, time.January, 20, 15, 0, 0, 0, time.UTC)
+
+	get := func(r http.Handler, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+		return rec
+	}
+
+	t.Run("it should count requests of the utc day and reject them over the limit", func(t *testing.T) {
+		now := time.Date(2024, time.January, 20, 23, 0, 0, 0, time.UTC)
+		store := fakefootballapi.NewStore(func() time.Time { return now })
+		require.NoError(t, store.Load(newScenario(startsAt)))
+		r := fakefootballapi.NewServer(store, 2).Router()
+
+		tests := []struct {
+			path              string
+			expectedStatus    int
+			expectedRemaining string
+		}{
+			{path: "/v3/fixtures?ids=101", expectedStatus: http.StatusOK, expectedRemaining: "1"},
+			{path: "/v3/leagues?season=2023", expectedStatus: http.StatusOK, expectedRemaining: "0"},
+			{path: "/v3/teams?league=39", expectedStatus: http.StatusTooManyRequests, expectedRemaining: "0"},
+			{path: "/v3/fixtures?ids=101", expectedStatus: http.StatusTooManyRequests, expectedRemaining: "0"},
+		}
+
+		for _, tt := range tests {
+			rec := get(r, tt.path)
+			assert.Equal(t, tt.expectedStatus, rec.Code, tt.path)
+			assert.Equal(t, "2", rec.Header().Get("X-RateLimit-Requests-Limit"), tt.path)
+			assert.Equal(t, tt.expectedRemaining, rec.Header().Get("X-RateLimit-Requests-Remaining"), tt.path)
+		}
+
+		assert.JSONEq(t, `{"message":"You have exceeded the DAILY quota for Requests on your current plan"}`, get(r, "/v3/fixtures?ids=101").Body.String())
+
+		status := get(r, "/v3/status")
+		assert.Equal(t, http.StatusOK, status.Code)
+		assert.Empty(t, status.Header().Get("X-RateLimit-Requests-Remaining"))
+		assert.JSONEq(t, `{"errors":[],"response":{"account":{"firstname":"fake","lastname":"football-api"},"requests":{"current":2,"limit_day":2}}}`, status.Body.String())
+
+		// the quota is reset at midnight utc
+		now = now.Add(time.Hour)
+		rec := get(r, "/v3/fixtures?ids=101")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "1", rec.Header().Get("X-RateLimit-Requests-Remaining"))
+	})
+
+	t.Run("it should not limit requests when the daily limit is not set", func(t *testing.T) {
+		store := fakefootballapi.NewStore(time.Now)
+		require.NoError(t, store.Load(newScenario(startsAt)))
+		r := fakefootballapi.NewServer(store, 0).Router()
+
+		for i := 0; i < 3; i++ {
+			rec := get(r, "/v3/fixtures?ids=101")
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Empty(t, rec.Header().Get("X-RateLimit-Requests-Limit"))
+		}
+	})
+}
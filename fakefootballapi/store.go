@@ -0,0 +1,288 @@
+package fakefootballapi
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/andrewshostak/result-service/client"
+)
+
+const (
+	statusNotStarted = "NS"
+	statusFirstHalf  = "1H"
+	statusSecondHalf = "2H"
+	statusExtraTime  = "ET"
+)
+
+var statusNames = map[string]string{
+	"TBD":  "Time To Be Defined",
+	"NS":   "Not Started",
+	"1H":   "First Half, Kick Off",
+	"HT":   "Halftime",
+	"2H":   "Second Half, 2nd Half Started",
+	"ET":   "Extra Time",
+	"BT":   "Break Time",
+	"P":    "Penalty In Progress",
+	"SUSP": "Match Suspended",
+	"INT":  "Match Interrupted",
+	"FT":   "Match Finished",
+	"AET":  "Match Finished After Extra Time",
+	"PEN":  "Match Finished After Penalty",
+	"PST":  "Match Postponed",
+	"CANC": "Match Cancelled",
+	"ABD":  "Match Abandoned",
+}
+
+// elapsed minute at the start of the period
+var periodStartMinutes = map[string]uint{
+	"1H": 1,
+	"HT": 45,
+	"2H": 46,
+	"BT": 90,
+	"ET": 91,
+	"P":  120,
+	"FT": 90,
+}
+
+type fixture struct {
+	scenario ScenarioFixture
+	startsAt time.Time
+	manual   *FixtureState
+}
+
+// Store keeps the scenario and calculates the state of fixtures at the current time.
+type Store struct {
+	mutex    sync.RWMutex
+	leagues  []ScenarioLeague
+	teams    map[uint]string
+	fixtures map[uint]*fixture
+	now      func() time.Time
+}
+
+func NewStore(now func() time.Time) *Store {
+	return &Store{teams: map[uint]string{}, fixtures: map[uint]*fixture{}, now: now}
+}
+
+// Load replaces the scenario.
+func (s *Store) Load(scenario Scenario) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.leagues = scenario.Leagues
+	s.teams = map[uint]string{}
+	for _, league := range scenario.Leagues {
+		for _, team := range league.Teams {
+			s.teams[team.ID] = team.Name
+		}
+	}
+
+	s.fixtures = map[uint]*fixture{}
+	for _, f := range scenario.Fixtures {
+		if err := s.addFixture(f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddFixture adds the fixture or replaces the existing one with the same id.
+func (s *Store) AddFixture(f ScenarioFixture) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.addFixture(f)
+}
+
+func (s *Store) addFixture(f ScenarioFixture) error {
+	if _, ok := s.teams[f.HomeTeamID]; !ok {
+		return fmt.Errorf("home team %d of fixture %d is not found in leagues", f.HomeTeamID, f.ID)
+	}
+
+	if _, ok := s.teams[f.AwayTeamID]; !ok {
+		return fmt.Errorf("away team %d of fixture %d is not found in leagues", f.AwayTeamID, f.ID)
+	}
+
+	var startsAt time.Time
+	switch {
+	case f.Date != nil:
+		startsAt = f.Date.UTC()
+	case f.StartsIn != nil:
+		startsAt = s.now().UTC().Add(time.Duration(*f.StartsIn)).Truncate(time.Minute)
+	default:
+		return fmt.Errorf("fixture %d must have date or starts_in", f.ID)
+	}
+
+	sort.SliceStable(f.Timeline, func(i, j int) bool { return f.Timeline[i].After < f.Timeline[j].After })
+
+	s.fixtures[f.ID] = &fixture{scenario: f, startsAt: startsAt}
+
+	return nil
+}
+
+// SetState sets the fixture state. The timeline of the fixture is not applied anymore.
+func (s *Store) SetState(id uint, state FixtureState) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	f, ok := s.fixtures[id]
+	if !ok {
+		return fmt.Errorf("fixture %d is not found", id)
+	}
+
+	if _, ok := statusNames[state.Status]; !ok {
+		return fmt.Errorf("unknown status %s", state.Status)
+	}
+
+	f.manual = &state
+
+	return nil
+}
+
+// FixtureSearch filters fixtures. Zero values are not applied.
+type FixtureSearch struct {
+	IDs    []uint
+	TeamID uint
+	Season uint
+	Date   string
+}
+
+func (s *Store) Fixtures(search FixtureSearch) []client.Result {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	now := s.now().UTC()
+
+	ids := map[uint]bool{}
+	for _, id := range search.IDs {
+		ids[id] = true
+	}
+
+	results := make([]client.Result, 0)
+	for _, f := range s.fixtures {
+		if len(ids) > 0 && !ids[f.scenario.ID] {
+			continue
+		}
+
+		if search.TeamID != 0 && f.scenario.HomeTeamID != search.TeamID && f.scenario.AwayTeamID != search.TeamID {
+			continue
+		}
+
+		if search.Season != 0 && f.scenario.Season != search.Season {
+			continue
+		}
+
+		if search.Date != "" && f.startsAt.Format(time.DateOnly) != search.Date {
+			continue
+		}
+
+		results = append(results, s.result(f, now))
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Fixture.ID < results[j].Fixture.ID })
+
+	return results
+}
+
+func (s *Store) Leagues(season uint) []client.LeagueResult {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	results := make([]client.LeagueResult, 0)
+	for _, league := range s.leagues {
+		if season != 0 && league.Season != season {
+			continue
+		}
+
+		results = append(results, client.LeagueResult{
+			League:  client.League{ID: league.ID, Name: league.Name},
+			Country: client.Country{Name: league.Country},
+		})
+	}
+
+	return results
+}
+
+func (s *Store) Teams(league uint, season uint) []client.TeamsResult {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	results := make([]client.TeamsResult, 0)
+	for _, l := range s.leagues {
+		if l.ID != league || (season != 0 && l.Season != season) {
+			continue
+		}
+
+		for _, team := range l.Teams {
+			results = append(results, client.TeamsResult{Team: client.Team{ID: team.ID, Name: team.Name}})
+		}
+	}
+
+	return results
+}
+
+func (s *Store) result(f *fixture, now time.Time) client.Result {
+	state := f.state(now)
+
+	league := client.FixtureLeague{ID: f.scenario.LeagueID, Round: f.scenario.Round}
+	for _, l := range s.leagues {
+		if l.ID == f.scenario.LeagueID {
+			league.Name = l.Name
+		}
+	}
+
+	goals := client.Goals{Home: state.Home, Away: state.Away}
+
+	return client.Result{
+		Fixture: client.Fixture{
+			ID:     f.scenario.ID,
+			Status: client.Status{Short: state.Status, Long: statusNames[state.Status], Elapsed: state.Elapsed},
+			Date:   f.startsAt.Format(time.RFC3339),
+		},
+		League: league,
+		Teams: client.Teams{
+			Home: client.Team{ID: f.scenario.HomeTeamID, Name: s.teams[f.scenario.HomeTeamID]},
+			Away: client.Team{ID: f.scenario.AwayTeamID, Name: s.teams[f.scenario.AwayTeamID]},
+		},
+		Goals: goals,
+		Score: client.Score{Fulltime: goals},
+	}
+}
+
+// state returns the manual state or the last timeline step which has already happened.
+func (f *fixture) state(now time.Time) FixtureState {
+	if f.manual != nil {
+		return *f.manual
+	}
+
+	state := FixtureState{Status: statusNotStarted}
+	var stepAt time.Time
+	for _, step := range f.scenario.Timeline {
+		at := f.startsAt.Add(time.Duration(step.After))
+		if at.After(now) {
+			break
+		}
+
+		state = FixtureState{Status: step.Status, Elapsed: step.Elapsed, Home: step.Home, Away: step.Away}
+		stepAt = at
+	}
+
+	minute, ok := periodStartMinutes[state.Status]
+	if state.Elapsed != nil {
+		minute, ok = *state.Elapsed, true
+	}
+
+	if !ok {
+		return state
+	}
+
+	if state.Status == statusFirstHalf || state.Status == statusSecondHalf || state.Status == statusExtraTime {
+		minute += uint(now.Sub(stepAt) / time.Minute)
+	}
+
+	state.Elapsed = &minute
+
+	return state
+}
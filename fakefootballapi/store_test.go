@@ -0,0 +1,117 @@
+package fakefootballapi_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andrewshostak/result-service/client"
+	"github.com/andrewshostak/result-service/fakefootballapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func duration(d time.Duration) fakefootballapi.Duration {
+	return fakefootballapi.Duration(d)
+}
+
+func newScenario(startsAt time.Time, timeline ...fakefootballapi.TimelineStep) fakefootballapi.Scenario {
+	return fakefootballapi.Scenario{
+		Leagues: []fakefootballapi.ScenarioLeague{{
+			ID:     39,
+			Name:   "Premier League",
+			Season: 2023,
+			Teams:  []fakefootballapi.ScenarioTeam{{ID: 42, Name: "Arsenal"}, {ID: 49, Name: "Chelsea"}},
+		}},
+		Fixtures: []fakefootballapi.ScenarioFixture{{
+			ID:         101,
+			LeagueID:   39,
+			Season:     2023,
+			Round:      "Regular Season - 21",
+			HomeTeamID: 42,
+			AwayTeamID: 49,
+			Date:       &startsAt,
+			Timeline:   timeline,
+		}},
+	}
+}
+
+func TestStore_Fixtures(t *testing.T) {
+	startsAt := time.Date(2024, time.January, 20, 15, 0, 0, 0, time.UTC)
+	extraTimeElapsed := uint(105)
+
+	// steps are sorted by After when the scenario is loaded
+	scenario := newScenario(startsAt,
+		fakefootballapi.TimelineStep{After: duration(47 * time.Minute), Status: "HT", Home: 1},
+		fakefootballapi.TimelineStep{After: 0, Status: "1H"},
+		fakefootballapi.TimelineStep{After: duration(62 * time.Minute), Status: "2H", Home: 1},
+		fakefootballapi.TimelineStep{After: duration(110 * time.Minute), Status: "BT", Home: 1, Away: 1},
+		fakefootballapi.TimelineStep{After: duration(115 * time.Minute), Status: "ET", Elapsed: &extraTimeElapsed, Home: 1, Away: 1},
+		fakefootballapi.TimelineStep{After: duration(140 * time.Minute), Status: "AET", Home: 2, Away: 1},
+	)
+
+	tests := []struct {
+		name            string
+		at              time.Duration
+		expectedStatus  client.Status
+		expectedElapsed *uint
+		expectedGoals   client.Goals
+	}{
+		{name: "it should be not started before the kick-off", at: -time.Minute, expectedStatus: client.Status{Short: "NS", Long: "Not Started"}},
+		{name: "it should count elapsed minutes of the first half from the period start", at: 10 * time.Minute, expectedStatus: client.Status{Short: "1H", Long: "First Half, Kick Off"}, expectedElapsed: uintPtr(11)},
+		{name: "it should keep the minute of the period start at halftime", at: 55 * time.Minute, expectedStatus: client.Status{Short: "HT", Long: "Halftime"}, expectedElapsed: uintPtr(45), expectedGoals: client.Goals{Home: 1}},
+		{name: "it should count elapsed minutes of the second half from the step", at: 72 * time.Minute, expectedStatus: client.Status{Short: "2H", Long: "Second Half, 2nd Half Started"}, expectedElapsed: uintPtr(56), expectedGoals: client.Goals{Home: 1}},
+		{name: "it should keep the minute of the break before extra time", at: 112 * time.Minute, expectedStatus: client.Status{Short: "BT", Long: "Break Time"}, expectedElapsed: uintPtr(90), expectedGoals: client.Goals{Home: 1, Away: 1}},
+		{name: "it should count elapsed minutes of extra time from the minute of the step", at: 120 * time.Minute, expectedStatus: client.Status{Short: "ET", Long: "Extra Time"}, expectedElapsed: uintPtr(110), expectedGoals: client.Goals{Home: 1, Away: 1}},
+		{name: "it should be finished after extra time at the last step", at: 3 * time.Hour, expectedStatus: client.Status{Short: "AET", Long: "Match Finished After Extra Time"}, expectedGoals: client.Goals{Home: 2, Away: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := startsAt.Add(tt.at)
+			store := fakefootballapi.NewStore(func() time.Time { return now })
+			require.NoError(t, store.Load(scenario))
+
+			fixtures := store.Fixtures(fakefootballapi.FixtureSearch{IDs: []uint{101}})
+			require.Len(t, fixtures, 1)
+
+			tt.expectedStatus.Elapsed = tt.expectedElapsed
+			assert.Equal(t, tt.expectedStatus, fixtures[0].Fixture.Status)
+			assert.Equal(t, tt.expectedGoals, fixtures[0].Goals)
+			assert.Equal(t, tt.expectedGoals, fixtures[0].Score.Fulltime)
+		})
+	}
+
+	t.Run("it should return the state set manually instead of the timeline", func(t *testing.T) {
+		now := startsAt.Add(30 * time.Minute)
+		store := fakefootballapi.NewStore(func() time.Time { return now })
+		require.NoError(t, store.Load(scenario))
+
+		require.NoError(t, store.SetState(101, fakefootballapi.FixtureState{Status: "PEN", Home: 3, Away: 3}))
+		assert.EqualError(t, store.SetState(101, fakefootballapi.FixtureState{Status: "LIVE"}), "unknown status LIVE")
+		assert.EqualError(t, store.SetState(102, fakefootballapi.FixtureState{Status: "FT"}), "fixture 102 is not found")
+
+		fixtures := store.Fixtures(fakefootballapi.FixtureSearch{TeamID: 49, Season: 2023, Date: "2024-01-20"})
+		require.Len(t, fixtures, 1)
+		assert.Equal(t, client.Status{Short: "PEN", Long: "Match Finished After Penalty"}, fixtures[0].Fixture.Status)
+		assert.Equal(t, client.Goals{Home: 3, Away: 3}, fixtures[0].Goals)
+	})
+
+	t.Run("it should set the kick-off relative to the time the fixture is added", func(t *testing.T) {
+		now := startsAt.Add(30*time.Second + 15*time.Millisecond)
+		store := fakefootballapi.NewStore(func() time.Time { return now })
+		require.NoError(t, store.Load(newScenario(startsAt)))
+
+		startsIn := duration(2 * time.Hour)
+		fixture := newScenario(startsAt).Fixtures[0]
+		fixture.ID, fixture.Date, fixture.StartsIn = 102, nil, &startsIn
+		require.NoError(t, store.AddFixture(fixture))
+
+		fixtures := store.Fixtures(fakefootballapi.FixtureSearch{IDs: []uint{102}})
+		require.Len(t, fixtures, 1)
+		assert.Equal(t, "2024-01-20T17:00:00Z", fixtures[0].Fixture.Date)
+	})
+}
+
+func uintPtr(v uint) *uint {
+	return &v
+}
@@ -1,11 +1,20 @@
 package handler
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"net/http"
 
+	"github.com/andrewshostak/result-service/errs"
+	"github.com/andrewshostak/result-service/middleware"
+	"github.com/andrewshostak/result-service/service"
 	"github.com/gin-gonic/gin"
 )
 
+// maxAliasFileSize limits the size of the imported file.
+const maxAliasFileSize = 10 << 20
+
 type AliasHandler struct {
 	aliasService AliasService
 }
@@ -17,17 +26,87 @@ func NewAliasHandler(aliasService AliasService) *AliasHandler {
 func (h *AliasHandler) Search(c *gin.Context) {
 	var params SearchAliasRequest
 	if err := c.ShouldBindQuery(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, middleware.ErrorBody(c, err.Error()))
 
 		return
 	}
 
 	result, err := h.aliasService.Search(c.Request.Context(), params.Search)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, middleware.ErrorBody(c, err.Error()))
 
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"aliases": result})
 }
+
+func (h *AliasHandler) Export(c *gin.Context) {
+	var params AliasFileRequest
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.ErrorBody(c, err.Error()))
+
+		return
+	}
+
+	teams, err := h.aliasService.Export(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, middleware.ErrorBody(c, err.Error()))
+
+		return
+	}
+
+	var buffer bytes.Buffer
+	if err := service.WriteTeamAliases(&buffer, params.Format, teams); err != nil {
+		c.JSON(http.StatusInternalServerError, middleware.ErrorBody(c, err.Error()))
+
+		return
+	}
+
+	contentType := "application/json"
+	if params.Format == service.AliasFileFormatCSV {
+		contentType = "text/csv"
+	}
+
+	c.Data(http.StatusOK, contentType, buffer.Bytes())
+}
+
+func (h *AliasHandler) Import(c *gin.Context) {
+	var params AliasFileRequest
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.ErrorBody(c, err.Error()))
+
+		return
+	}
+
+	teams, err := service.ReadTeamAliases(http.MaxBytesReader(c.Writer, c.Request.Body, maxAliasFileSize), params.Format)
+	var errMaxBytes *http.MaxBytesError
+	if errors.As(err, &errMaxBytes) {
+		c.JSON(http.StatusRequestEntityTooLarge, middleware.ErrorBody(c, fmt.Sprintf("file is larger than %d bytes", errMaxBytes.Limit)))
+
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, middleware.ErrorBody(c, err.Error()))
+
+		return
+	}
+
+	result, err := h.aliasService.Import(c.Request.Context(), teams)
+	if errors.As(err, &errs.AliasImportConflictError{}) {
+		body := middleware.ErrorBody(c, err.Error())
+		body["result"] = fromDomainAliasImportResult(*result)
+		c.JSON(http.StatusConflict, body)
+
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, middleware.ErrorBody(c, err.Error()))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": fromDomainAliasImportResult(*result)})
+}
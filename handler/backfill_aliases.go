@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BackfillAliasesHandler struct {
+	backfillAliasesService BackfillAliasesService
+}
+
+func NewBackfillAliasesHandler(backfillAliasesService BackfillAliasesService) *BackfillAliasesHandler {
+	return &BackfillAliasesHandler{backfillAliasesService: backfillAliasesService}
+}
+
+func (h *BackfillAliasesHandler) Status(c *gin.Context) {
+	run := h.backfillAliasesService.LastRun()
+	if run == nil {
+		c.JSON(http.StatusOK, gin.H{"last_run": nil})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"last_run": fromDomainBackfillRun(*run)})
+}
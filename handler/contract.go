@@ -7,9 +7,24 @@ import (
 )
 
 type AliasService interface {
+	Export(ctx context.Context) ([]service.TeamAliases, error)
+	Import(ctx context.Context, teams []service.TeamAliases) (*service.AliasImportResult, error)
 	Search(ctx context.Context, alias string) ([]string, error)
 }
 
+type BackfillAliasesService interface {
+	LastRun() *service.BackfillRun
+}
+
+type FootballAPIQuotaService interface {
+	Quota() *service.FootballAPIQuota
+}
+
+type HealthService interface {
+	Liveness(ctx context.Context) service.HealthReport
+	Readiness(ctx context.Context) service.HealthReport
+}
+
 type MatchService interface {
 	Create(ctx context.Context, request service.CreateMatchRequest) (uint, error)
 }
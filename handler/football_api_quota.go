@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FootballAPIQuotaHandler struct {
+	footballAPIQuotaService FootballAPIQuotaService
+}
+
+func NewFootballAPIQuotaHandler(footballAPIQuotaService FootballAPIQuotaService) *FootballAPIQuotaHandler {
+	return &FootballAPIQuotaHandler{footballAPIQuotaService: footballAPIQuotaService}
+}
+
+func (h *FootballAPIQuotaHandler) Get(c *gin.Context) {
+	quota := h.footballAPIQuotaService.Quota()
+	if quota == nil {
+		c.JSON(http.StatusOK, gin.H{"quota": nil})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"quota": fromDomainFootballAPIQuota(*quota)})
+}
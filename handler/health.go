@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/andrewshostak/result-service/service"
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	healthService HealthService
+}
+
+func NewHealthHandler(healthService HealthService) *HealthHandler {
+	return &HealthHandler{healthService: healthService}
+}
+
+func (h *HealthHandler) Liveness(c *gin.Context) {
+	respondWithHealthReport(c, h.healthService.Liveness(c.Request.Context()))
+}
+
+func (h *HealthHandler) Readiness(c *gin.Context) {
+	respondWithHealthReport(c, h.healthService.Readiness(c.Request.Context()))
+}
+
+func respondWithHealthReport(c *gin.Context, report service.HealthReport) {
+	status := http.StatusOK
+	if report.Status == service.HealthStatusUnavailable {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, fromDomainHealthReport(report))
+}
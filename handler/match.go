@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/andrewshostak/result-service/errs"
+	"github.com/andrewshostak/result-service/middleware"
 	"github.com/gin-gonic/gin"
 )
 
@@ -19,26 +20,32 @@ func NewMatchHandler(matchService MatchService) *MatchHandler {
 func (h *MatchHandler) Create(c *gin.Context) {
 	var params CreateMatchRequest
 	if err := c.ShouldBindJSON(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, middleware.ErrorBody(c, err.Error()))
 
 		return
 	}
 
 	result, err := h.matchService.Create(c.Request.Context(), params.ToDomain())
 	if errors.As(err, &errs.AliasNotFoundError{}) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, middleware.ErrorBody(c, err.Error()))
 
 		return
 	}
 
 	if errors.As(err, &errs.UnexpectedNumberOfItemsError{}) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, middleware.ErrorBody(c, err.Error()))
+
+		return
+	}
+
+	if errors.As(err, &errs.AmbiguousFixtureError{}) {
+		c.JSON(http.StatusConflict, middleware.ErrorBody(c, err.Error()))
 
 		return
 	}
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, middleware.ErrorBody(c, err.Error()))
 
 		return
 	}
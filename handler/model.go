@@ -30,6 +30,23 @@ type SearchAliasRequest struct {
 	Search string `form:"search" binding:"required"`
 }
 
+type AliasFileRequest struct {
+	Format string `form:"format,default=json" binding:"oneof=csv json"`
+}
+
+type AliasImportResponse struct {
+	CreatedTeams            int                   `json:"created_teams"`
+	CreatedAliases          int                   `json:"created_aliases"`
+	CreatedFootballAPITeams int                   `json:"created_football_api_teams"`
+	Conflicts               []AliasImportConflict `json:"conflicts"`
+}
+
+type AliasImportConflict struct {
+	TeamID uint   `json:"team_id"`
+	Alias  string `json:"alias,omitempty"`
+	Reason string `json:"reason"`
+}
+
 func (cmr *CreateMatchRequest) ToDomain() service.CreateMatchRequest {
 	return service.CreateMatchRequest{
 		StartsAt:  cmr.StartsAt,
@@ -55,3 +72,122 @@ func (dsr *DeleteSubscriptionRequest) ToDomain() service.DeleteSubscriptionReque
 		SecretKey: dsr.SecretKey,
 	}
 }
+
+type BackfillAliasesStatusResponse struct {
+	Running    bool                          `json:"running"`
+	Season     uint                          `json:"season"`
+	Trigger    string                        `json:"trigger"`
+	StartedAt  time.Time                     `json:"started_at"`
+	FinishedAt *time.Time                    `json:"finished_at"`
+	Error      *string                       `json:"error"`
+	Leagues    []BackfillAliasesLeagueResult `json:"leagues"`
+}
+
+type BackfillAliasesLeagueResult struct {
+	League    string  `json:"league"`
+	Country   string  `json:"country"`
+	Created   int     `json:"created"`
+	Renamed   int     `json:"renamed"`
+	Existing  int     `json:"existing"`
+	Conflicts int     `json:"conflicts"`
+	Failed    int     `json:"failed"`
+	Error     *string `json:"error"`
+}
+
+func fromDomainBackfillRun(run service.BackfillRun) BackfillAliasesStatusResponse {
+	response := BackfillAliasesStatusResponse{
+		Running:    run.FinishedAt == nil,
+		Season:     run.Season,
+		Trigger:    run.Trigger,
+		StartedAt:  run.StartedAt,
+		FinishedAt: run.FinishedAt,
+		Error:      errorMessage(run.Err),
+		Leagues:    []BackfillAliasesLeagueResult{},
+	}
+
+	if run.Report == nil {
+		return response
+	}
+
+	for _, l := range run.Report.Leagues {
+		response.Leagues = append(response.Leagues, BackfillAliasesLeagueResult{
+			League:    l.League.League.Name,
+			Country:   l.League.Country.Name,
+			Created:   len(l.Created),
+			Renamed:   len(l.Renamed),
+			Existing:  len(l.Existing),
+			Conflicts: len(l.Conflicts),
+			Failed:    len(l.Failed),
+			Error:     errorMessage(l.Err),
+		})
+	}
+
+	return response
+}
+
+func errorMessage(err error) *string {
+	if err == nil {
+		return nil
+	}
+
+	message := err.Error()
+	return &message
+}
+
+func fromDomainAliasImportResult(result service.AliasImportResult) AliasImportResponse {
+	conflicts := make([]AliasImportConflict, 0, len(result.Conflicts))
+	for _, c := range result.Conflicts {
+		conflicts = append(conflicts, AliasImportConflict{TeamID: c.TeamID, Alias: c.Alias, Reason: c.Reason})
+	}
+
+	return AliasImportResponse{
+		CreatedTeams:            result.CreatedTeams,
+		CreatedAliases:          result.CreatedAliases,
+		CreatedFootballAPITeams: result.CreatedFootballAPITeams,
+		Conflicts:               conflicts,
+	}
+}
+
+type FootballAPIQuotaResponse struct {
+	DailyLimit      *int      `json:"daily_limit"`
+	DailyRemaining  *int      `json:"daily_remaining"`
+	MinuteLimit     *int      `json:"minute_limit"`
+	MinuteRemaining *int      `json:"minute_remaining"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func fromDomainFootballAPIQuota(quota service.FootballAPIQuota) FootballAPIQuotaResponse {
+	return FootballAPIQuotaResponse{
+		DailyLimit:      quota.DailyLimit,
+		DailyRemaining:  quota.DailyRemaining,
+		MinuteLimit:     quota.MinuteLimit,
+		MinuteRemaining: quota.MinuteRemaining,
+		UpdatedAt:       quota.UpdatedAt,
+	}
+}
+
+type HealthResponse struct {
+	Status string                         `json:"status"`
+	Checks map[string]HealthCheckResponse `json:"checks"`
+}
+
+type HealthCheckResponse struct {
+	Status   string         `json:"status"`
+	Critical bool           `json:"critical"`
+	Error    *string        `json:"error,omitempty"`
+	Details  map[string]any `json:"details,omitempty"`
+}
+
+func fromDomainHealthReport(report service.HealthReport) HealthResponse {
+	checks := make(map[string]HealthCheckResponse, len(report.Checks))
+	for _, check := range report.Checks {
+		checks[check.Name] = HealthCheckResponse{
+			Status:   check.Status,
+			Critical: check.Critical,
+			Error:    errorMessage(check.Err),
+			Details:  check.Details,
+		}
+	}
+
+	return HealthResponse{Status: report.Status, Checks: checks}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/andrewshostak/result-service/errs"
+	"github.com/andrewshostak/result-service/middleware"
 	"github.com/gin-gonic/gin"
 )
 
@@ -19,7 +20,7 @@ func NewSubscriptionHandler(subscriptionService SubscriptionService) *Subscripti
 func (h *SubscriptionHandler) Create(c *gin.Context) {
 	var params CreateSubscriptionRequest
 	if err := c.ShouldBindJSON(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, middleware.ErrorBody(c, err.Error()))
 
 		return
 	}
@@ -32,13 +33,13 @@ func (h *SubscriptionHandler) Create(c *gin.Context) {
 	}
 
 	if errors.As(err, &errs.WrongMatchIDError{}) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, middleware.ErrorBody(c, err.Error()))
 
 		return
 	}
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, middleware.ErrorBody(c, err.Error()))
 
 		return
 	}
@@ -49,37 +50,37 @@ func (h *SubscriptionHandler) Create(c *gin.Context) {
 func (h *SubscriptionHandler) Delete(c *gin.Context) {
 	var params DeleteSubscriptionRequest
 	if err := c.ShouldBindQuery(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, middleware.ErrorBody(c, err.Error()))
 		return
 	}
 
 	err := h.subscriptionService.Delete(c.Request.Context(), params.ToDomain())
 	if errors.As(err, &errs.AliasNotFoundError{}) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, middleware.ErrorBody(c, err.Error()))
 
 		return
 	}
 
 	if errors.As(err, &errs.MatchNotFoundError{}) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, middleware.ErrorBody(c, err.Error()))
 
 		return
 	}
 
 	if errors.As(err, &errs.SubscriptionNotFoundError{}) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, middleware.ErrorBody(c, err.Error()))
 
 		return
 	}
 
 	if errors.As(err, &errs.SubscriptionWrongStatusError{}) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, middleware.ErrorBody(c, err.Error()))
 
 		return
 	}
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, middleware.ErrorBody(c, err.Error()))
 
 		return
 	}
@@ -0,0 +1,78 @@
+package initializer
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	backfillAliasesCronKey        = "backfill-aliases-cron"
+	backfillAliasesSeasonStartKey = "backfill-aliases-season-start"
+
+	triggerSchedule    = "schedule"
+	triggerSeasonStart = "season_start"
+)
+
+type BackfillAliasesScheduleInitializer struct {
+	backfillAliasesService BackfillAliasesService
+	taskScheduler          TaskScheduler
+	logger                 Logger
+	schedule               string
+}
+
+func NewBackfillAliasesScheduleInitializer(
+	backfillAliasesService BackfillAliasesService,
+	taskScheduler TaskScheduler,
+	logger Logger,
+	schedule string,
+) *BackfillAliasesScheduleInitializer {
+	return &BackfillAliasesScheduleInitializer{
+		backfillAliasesService: backfillAliasesService,
+		taskScheduler:          taskScheduler,
+		logger:                 logger,
+		schedule:               schedule,
+	}
+}
+
+// Start schedules aliases backfill by cron expression and at the start of the next season.
+func (i *BackfillAliasesScheduleInitializer) Start() error {
+	if err := i.taskScheduler.ScheduleWithCron(backfillAliasesCronKey, i.getTaskFunc(triggerSchedule), i.schedule); err != nil {
+		return fmt.Errorf("failed to schedule aliases backfill with %s expression: %w", i.schedule, err)
+	}
+
+	i.logger.Info().Str("schedule", i.schedule).Msg("aliases backfill scheduled")
+
+	return i.scheduleSeasonStart()
+}
+
+func (i *BackfillAliasesScheduleInitializer) scheduleSeasonStart() error {
+	seasonStart := i.backfillAliasesService.NextSeasonStart()
+
+	err := i.taskScheduler.ScheduleOnce(backfillAliasesSeasonStartKey, func(ctx context.Context) {
+		i.getTaskFunc(triggerSeasonStart)(ctx)
+
+		if err := i.scheduleSeasonStart(); err != nil {
+			i.logger.Error().Err(err).Msg("failed to schedule aliases backfill at the next season start")
+		}
+	}, seasonStart)
+	if err != nil {
+		return fmt.Errorf("failed to schedule aliases backfill at the season start: %w", err)
+	}
+
+	i.logger.Info().Time("season_start", seasonStart).Msg("aliases backfill scheduled at the season start")
+
+	return nil
+}
+
+func (i *BackfillAliasesScheduleInitializer) getTaskFunc(trigger string) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		i.logger.Info().Str("trigger", trigger).Msg("starting scheduled aliases backfill")
+
+		if err := i.backfillAliasesService.BackfillCurrentSeason(ctx, trigger); err != nil {
+			i.logger.Error().Err(err).Str("trigger", trigger).Msg("scheduled aliases backfill failed")
+			return
+		}
+
+		i.logger.Info().Str("trigger", trigger).Msg("scheduled aliases backfill finished")
+	}
+}
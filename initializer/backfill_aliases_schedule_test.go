@@ -0,0 +1,70 @@
+package initializer_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/andrewshostak/result-service/initializer"
+	"github.com/andrewshostak/result-service/initializer/mocks"
+	"github.com/andrewshostak/result-service/scheduler"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBackfillAliasesScheduleInitializer_Start(t *testing.T) {
+	now := time.Date(2024, time.January, 10, 12, 0, 0, 0, time.UTC)
+	seasonStart := time.Date(2024, time.June, 3, 0, 0, 0, 1, time.UTC)
+	nextSeasonStart := time.Date(2025, time.June, 3, 0, 0, 0, 1, time.UTC)
+	// the cron task runs on January 1, so it runs once between the season starts
+	schedule := "0 0 0 1 1 *"
+
+	t.Run("it should run backfill at the season start and schedule it at the next season start", func(t *testing.T) {
+		fakeClock := clock.NewFake(now)
+		backfillAliasesService := mocks.NewBackfillAliasesService(t)
+		logger := zerolog.Nop()
+
+		backfillAliasesService.On("NextSeasonStart").Return(seasonStart).Once()
+		i := initializer.NewBackfillAliasesScheduleInitializer(backfillAliasesService, scheduler.NewTaskScheduler(fakeClock), &logger, schedule)
+		assert.NoError(t, i.Start())
+
+		fakeClock.Set(seasonStart.Add(-time.Nanosecond))
+
+		backfillAliasesService.On("BackfillCurrentSeason", mock.Anything, "season_start").Return(nil).Once()
+		backfillAliasesService.On("NextSeasonStart").Return(nextSeasonStart).Once()
+		fakeClock.Set(seasonStart)
+
+		backfillAliasesService.On("BackfillCurrentSeason", mock.Anything, "schedule").Return(nil).Once()
+		backfillAliasesService.On("BackfillCurrentSeason", mock.Anything, "season_start").Return(nil).Once()
+		backfillAliasesService.On("NextSeasonStart").Return(nextSeasonStart.AddDate(1, 0, 0)).Once()
+		fakeClock.Set(nextSeasonStart)
+	})
+
+	t.Run("it should schedule the next season start when backfill fails", func(t *testing.T) {
+		fakeClock := clock.NewFake(now)
+		backfillAliasesService := mocks.NewBackfillAliasesService(t)
+		logger := zerolog.Nop()
+
+		backfillAliasesService.On("NextSeasonStart").Return(seasonStart).Once()
+		i := initializer.NewBackfillAliasesScheduleInitializer(backfillAliasesService, scheduler.NewTaskScheduler(fakeClock), &logger, schedule)
+		assert.NoError(t, i.Start())
+
+		backfillAliasesService.On("BackfillCurrentSeason", mock.Anything, "season_start").Return(errors.New("aliases backfill is already running")).Once()
+		backfillAliasesService.On("NextSeasonStart").Return(nextSeasonStart).Once()
+		fakeClock.Set(seasonStart)
+
+		backfillAliasesService.On("BackfillCurrentSeason", mock.Anything, "schedule").Return(nil).Once()
+		backfillAliasesService.On("BackfillCurrentSeason", mock.Anything, "season_start").Return(nil).Once()
+		backfillAliasesService.On("NextSeasonStart").Return(nextSeasonStart.AddDate(1, 0, 0)).Once()
+		fakeClock.Set(nextSeasonStart)
+	})
+
+	t.Run("it should return an error when the cron expression is invalid", func(t *testing.T) {
+		logger := zerolog.Nop()
+
+		i := initializer.NewBackfillAliasesScheduleInitializer(mocks.NewBackfillAliasesService(t), scheduler.NewTaskScheduler(clock.NewFake(now)), &logger, "every day")
+		assert.ErrorContains(t, i.Start(), "failed to schedule aliases backfill with every day expression")
+	})
+}
@@ -2,7 +2,9 @@ package initializer
 
 import (
 	"context"
+	"time"
 
+	"github.com/andrewshostak/result-service/clock"
 	"github.com/andrewshostak/result-service/service"
 	"github.com/rs/zerolog"
 )
@@ -13,11 +15,38 @@ type MatchService interface {
 	Update(ctx context.Context, id uint, status string) error
 }
 
+type BackfillAliasesService interface {
+	BackfillCurrentSeason(ctx context.Context, trigger string) error
+	NextSeasonStart() time.Time
+}
+
+type TaskScheduler interface {
+	ScheduleOnce(key string, task func(ctx context.Context), startTime time.Time) error
+	ScheduleWithCron(key string, task func(ctx context.Context), expression string) error
+}
+
 type NotifierService interface {
 	NotifySubscribers(ctx context.Context) error
+	NotifyMatchSubscribers(ctx context.Context, matchID uint) error
+}
+
+// MatchResultEvents receives ids of matches which results are saved.
+type MatchResultEvents interface {
+	MatchResults() <-chan uint
+}
+
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) clock.Ticker
 }
 
 type Logger interface {
+	Debug() *zerolog.Event
 	Error() *zerolog.Event
 	Info() *zerolog.Event
+	Warn() *zerolog.Event
+}
+
+type Metrics interface {
+	NotifierRunFailed()
 }
@@ -0,0 +1,66 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// BackfillAliasesService is an autogenerated mock type for the BackfillAliasesService type
+type BackfillAliasesService struct {
+	mock.Mock
+}
+
+// BackfillCurrentSeason provides a mock function with given fields: ctx, trigger
+func (_m *BackfillAliasesService) BackfillCurrentSeason(ctx context.Context, trigger string) error {
+	ret := _m.Called(ctx, trigger)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BackfillCurrentSeason")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, trigger)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NextSeasonStart provides a mock function with no fields
+func (_m *BackfillAliasesService) NextSeasonStart() time.Time {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NextSeasonStart")
+	}
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
+// NewBackfillAliasesService creates a new instance of BackfillAliasesService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBackfillAliasesService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BackfillAliasesService {
+	mock := &BackfillAliasesService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
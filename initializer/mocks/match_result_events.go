@@ -0,0 +1,44 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// MatchResultEvents is an autogenerated mock type for the MatchResultEvents type
+type MatchResultEvents struct {
+	mock.Mock
+}
+
+// MatchResults provides a mock function with no fields
+func (_m *MatchResultEvents) MatchResults() <-chan uint {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MatchResults")
+	}
+
+	var r0 <-chan uint
+	if rf, ok := ret.Get(0).(func() <-chan uint); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan uint)
+		}
+	}
+
+	return r0
+}
+
+// NewMatchResultEvents creates a new instance of MatchResultEvents. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMatchResultEvents(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MatchResultEvents {
+	mock := &MatchResultEvents{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
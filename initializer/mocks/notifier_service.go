@@ -0,0 +1,64 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NotifierService is an autogenerated mock type for the NotifierService type
+type NotifierService struct {
+	mock.Mock
+}
+
+// NotifyMatchSubscribers provides a mock function with given fields: ctx, matchID
+func (_m *NotifierService) NotifyMatchSubscribers(ctx context.Context, matchID uint) error {
+	ret := _m.Called(ctx, matchID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotifyMatchSubscribers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, matchID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotifySubscribers provides a mock function with given fields: ctx
+func (_m *NotifierService) NotifySubscribers(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotifySubscribers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewNotifierService creates a new instance of NotifierService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotifierService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotifierService {
+	mock := &NotifierService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
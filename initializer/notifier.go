@@ -2,32 +2,208 @@ package initializer
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 )
 
-const checkSubscribersTime = 1 * time.Minute
+// maxNotifierBackoff limits the delay of the next run after consecutive failed runs, unless the interval is longer
+const maxNotifierBackoff = 10 * time.Minute
 
 type NotifierInitializer struct {
 	notifierService NotifierService
+	events          MatchResultEvents
+	logger          Logger
+	clock           Clock
+	metrics         Metrics
+	interval        time.Duration
+
+	mutex     sync.RWMutex
+	lastTick  time.Time
+	running   bool
+	failures  uint
+	lastError error
+	nextRunAt time.Time
+
+	// done is closed when the loop exits, runCtx is cancelled when the last run doesn't finish before Wait deadline
+	done      chan struct{}
+	runCtx    context.Context
+	cancelRun context.CancelFunc
 }
 
-func NewNotifierInitializer(notifierService NotifierService) *NotifierInitializer {
-	return &NotifierInitializer{notifierService: notifierService}
+func NewNotifierInitializer(
+	notifierService NotifierService,
+	events MatchResultEvents,
+	logger Logger,
+	clock Clock,
+	metrics Metrics,
+	interval time.Duration,
+) *NotifierInitializer {
+	runCtx, cancelRun := context.WithCancel(context.Background())
+
+	return &NotifierInitializer{
+		notifierService: notifierService,
+		events:          events,
+		logger:          logger,
+		clock:           clock,
+		metrics:         metrics,
+		interval:        interval,
+		done:            make(chan struct{}),
+		runCtx:          runCtx,
+		cancelRun:       cancelRun,
+	}
 }
 
-func (i *NotifierInitializer) Start() {
-	ticker := time.NewTicker(checkSubscribersTime)
+// Start runs the notifier loop until ctx is cancelled. A run in progress is not interrupted by ctx, use Wait to wait for it.
+// Subscribers of a match are notified as soon as its result event is received. The periodic sweep notifies
+// the rest, e.g. when an event is lost. A failed sweep doesn't stop the loop, the next sweeps are delayed
+// with exponential backoff until a sweep succeeds. Events and sweeps are handled one by one, so a subscription
+// is not notified twice by the instance.
+func (i *NotifierInitializer) Start(ctx context.Context) {
+	ticker := i.clock.NewTicker(i.interval)
+	i.tick()
 
 	go func() {
+		defer close(i.done)
+		defer ticker.Stop()
+
 		for {
 			select {
-			case <-ticker.C:
-				ctx := context.Background()
-				err := i.notifierService.NotifySubscribers(ctx)
+			case <-ctx.Done():
+				return
+			case matchID := <-i.events.MatchResults():
+				i.startRun()
+				err := i.notifierService.NotifyMatchSubscribers(i.runCtx, matchID)
+				i.stopRun()
+				if err != nil && i.runCtx.Err() != nil {
+					return
+				}
+
 				if err != nil {
-					panic(err)
+					i.metrics.NotifierRunFailed()
+					i.logger.Error().Err(err).Uint("match_id", matchID).Msg("failed to notify match subscribers. they are notified by the next sweep")
 				}
+			case <-ticker.C():
+				startedAt := i.clock.Now()
+				if startedAt.Before(i.nextRun()) {
+					i.tick()
+					continue
+				}
+
+				i.startRun()
+				err := i.notifierService.NotifySubscribers(i.runCtx)
+				i.stopRun()
+				if err != nil && i.runCtx.Err() != nil {
+					return
+				}
+
+				i.finishRun(startedAt, err)
 			}
 		}
 	}()
 }
+
+// Wait waits for the loop to exit after the context of Start is cancelled. When ctx is done before the run in progress
+// finishes, the run is cancelled and ctx error is returned.
+func (i *NotifierInitializer) Wait(ctx context.Context) error {
+	select {
+	case <-i.done:
+		return nil
+	case <-ctx.Done():
+		i.cancelRun()
+		return fmt.Errorf("notifier run is not finished: %w", ctx.Err())
+	}
+}
+
+// LastTick returns the time when the notifier loop was started, handled its last tick or started its last run.
+// A sweep of several batches can take longer than a few intervals, so the current time is returned while a run is in progress.
+// It is zero before Start.
+func (i *NotifierInitializer) LastTick() time.Time {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	if i.running {
+		return i.clock.Now()
+	}
+
+	return i.lastTick
+}
+
+// Interval is the time between notifier runs.
+func (i *NotifierInitializer) Interval() time.Duration {
+	return i.interval
+}
+
+// Failures returns the number of consecutive failed runs and the error of the last one.
+func (i *NotifierInitializer) Failures() (uint, error) {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	return i.failures, i.lastError
+}
+
+// finishRun records the result of the run. The backoff is counted from the start of the run, so it matches the ticks.
+func (i *NotifierInitializer) finishRun(startedAt time.Time, err error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if err == nil {
+		i.failures = 0
+		i.lastError = nil
+		i.nextRunAt = time.Time{}
+		return
+	}
+
+	i.failures++
+	i.lastError = err
+	i.nextRunAt = startedAt.Add(notifierBackoff(i.interval, i.failures))
+	i.metrics.NotifierRunFailed()
+
+	i.logger.Error().Err(err).
+		Uint("consecutive_failures", i.failures).
+		Time("next_run_at", i.nextRunAt).
+		Msg("failed to notify subscribers")
+}
+
+func (i *NotifierInitializer) nextRun() time.Time {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	return i.nextRunAt
+}
+
+// startRun records the tick when the run starts, the loop is considered alive until the run stops.
+func (i *NotifierInitializer) startRun() {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.lastTick = i.clock.Now()
+	i.running = true
+}
+
+func (i *NotifierInitializer) stopRun() {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.lastTick = i.clock.Now()
+	i.running = false
+}
+
+func (i *NotifierInitializer) tick() {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.lastTick = i.clock.Now()
+}
+
+// notifierBackoff doubles the interval for each consecutive failure after the first one.
+func notifierBackoff(interval time.Duration, failures uint) time.Duration {
+	limit := max(interval, maxNotifierBackoff)
+
+	backoff := interval
+	for n := uint(1); n < failures && backoff < limit; n++ {
+		backoff *= 2
+	}
+
+	return min(backoff, limit)
+}
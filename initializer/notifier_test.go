@@ -0,0 +1,57 @@
+package initializer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/andrewshostak/result-service/initializer"
+	"github.com/andrewshostak/result-service/initializer/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNotifierInitializer_LastTick(t *testing.T) {
+	now := time.Date(2024, time.January, 20, 17, 0, 0, 0, time.UTC)
+	interval := time.Minute
+
+	t.Run("it should report the notifier alive while a slow sweep is in progress", func(t *testing.T) {
+		fakeClock := clock.NewFake(now)
+		notifierService := mocks.NewNotifierService(t)
+		events := mocks.NewMatchResultEvents(t)
+		logger := zerolog.Nop()
+
+		events.On("MatchResults").Return((<-chan uint)(make(chan uint)))
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		notifierService.On("NotifySubscribers", mock.Anything).Run(func(mock.Arguments) {
+			close(started)
+			<-release
+		}).Return(nil).Once()
+		// a tick dropped during the slow sweep may start one more sweep
+		notifierService.On("NotifySubscribers", mock.Anything).Return(nil).Maybe()
+
+		i := initializer.NewNotifierInitializer(notifierService, events, &logger, fakeClock, mocks.NewMetrics(t), interval)
+		assert.True(t, i.LastTick().IsZero())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		i.Start(ctx)
+		assert.Equal(t, now, i.LastTick())
+
+		fakeClock.Advance(interval)
+		<-started
+
+		fakeClock.Advance(10 * interval)
+		assert.Equal(t, now.Add(11*interval), i.LastTick())
+
+		close(release)
+		cancel()
+		assert.NoError(t, i.Wait(context.Background()))
+
+		fakeClock.Advance(5 * interval)
+		assert.Equal(t, now.Add(11*interval), i.LastTick())
+	})
+}
@@ -0,0 +1,250 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andrewshostak/result-service/app"
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/andrewshostak/result-service/config"
+	"github.com/andrewshostak/result-service/fakefootballapi"
+	"github.com/andrewshostak/result-service/repository"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const (
+	apiKey    = "integration-api-key"
+	secretKey = "integration-secret"
+)
+
+type notification struct {
+	Authorization string
+	Body          map[string]uint
+}
+
+// subscriber records notifications sent by the service.
+type subscriber struct {
+	server        *httptest.Server
+	mutex         sync.Mutex
+	notifications []notification
+}
+
+func newSubscriber(t *testing.T) *subscriber {
+	s := &subscriber{}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]uint
+		if r.Method != http.MethodPatch || json.NewDecoder(r.Body).Decode(&body) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		s.mutex.Lock()
+		s.notifications = append(s.notifications, notification{Authorization: r.Header.Get("Authorization"), Body: body})
+		s.mutex.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(s.server.Close)
+
+	return s
+}
+
+func (s *subscriber) Notifications() []notification {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]notification(nil), s.notifications...)
+}
+
+type harnessOptions struct {
+	pollingMaxRetries uint
+}
+
+// harness runs the app with its real router and repositories against the test database and the fake football-api.
+// The app and the fake football-api share the fake clock, so tests play a match by advancing it.
+type harness struct {
+	t           *testing.T
+	app         *app.App
+	server      *httptest.Server
+	db          *gorm.DB
+	clock       *clock.Fake
+	store       *fakefootballapi.Store
+	apiRequests *atomic.Int64
+	subscriber  *subscriber
+}
+
+func newHarness(t *testing.T, options harnessOptions) *harness {
+	t.Helper()
+
+	fakeClock := clock.NewFake(time.Now().UTC().Truncate(time.Minute))
+	store := fakefootballapi.NewStore(fakeClock.Now)
+
+	apiRequests := &atomic.Int64{}
+	fakeRouter := fakefootballapi.NewServer(store, 0).Router()
+	fakeAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiRequests.Add(1)
+		fakeRouter.ServeHTTP(w, r)
+	}))
+	t.Cleanup(fakeAPI.Close)
+
+	cfg := config.Config{
+		App: config.App{HashedAPIKeys: []string{hashAPIKey(apiKey)}, SecretKey: secretKey},
+		ExternalAPI: config.ExternalAPI{
+			RapidAPIKey:                    "test",
+			FootballAPIBaseURL:             fakeAPI.URL,
+			FootballAPITimeout:             5 * time.Second,
+			FootballAPIBreakerThreshold:    100,
+			FootballAPIBreakerOpenDuration: time.Second,
+			FootballAPIBatchWindow:         10 * time.Millisecond,
+		},
+		Result: config.ResultPolling{
+			PollingMaxRetries:         options.pollingMaxRetries,
+			PollingInterval:           15 * time.Minute,
+			PollingFirstAttemptDelay:  115 * time.Minute,
+			PollingMaxDuration:        24 * time.Hour,
+			KnockoutFirstAttemptDelay: 145 * time.Minute,
+			LivePollingInterval:       2 * time.Minute,
+			HalfTimeInterval:          10 * time.Minute,
+			LiveMaxDuration:           4 * time.Hour,
+			VerificationMode:          "off",
+		},
+		BackfillAliases: config.BackfillAliases{Workers: 1},
+		Notifier:        config.Notifier{Interval: time.Minute, Events: "local", Workers: 4, PerHostConcurrency: 2, DeliveryTimeout: 5 * time.Second},
+		PG:              pg,
+	}
+
+	db := repository.EstablishDatabaseConnection(cfg)
+	require.NoError(t, db.Exec("truncate notification_outbox, subscriptions, football_api_fixtures, matches, aliases, football_api_teams, teams restart identity cascade").Error)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	a, err := app.New(cfg, db, &logger, fakeClock)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, a.Start(ctx))
+	t.Cleanup(func() {
+		cancel()
+		shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelShutdown()
+		assert.NoError(t, a.Shutdown(shutdownCtx))
+	})
+
+	server := httptest.NewServer(a.Router)
+	t.Cleanup(server.Close)
+
+	return &harness{
+		t:           t,
+		app:         a,
+		server:      server,
+		db:          db,
+		clock:       fakeClock,
+		store:       store,
+		apiRequests: apiRequests,
+		subscriber:  newSubscriber(t),
+	}
+}
+
+// do sends an authorized request to the service and decodes the response body into out when it is not nil.
+func (h *harness) do(method string, path string, body any, out any) int {
+	h.t.Helper()
+
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case string:
+		reader = bytes.NewBufferString(b)
+	default:
+		payload, err := json.Marshal(b)
+		require.NoError(h.t, err)
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, h.server.URL+path, reader)
+	require.NoError(h.t, err)
+	req.Header.Set("Authorization", apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := h.server.Client().Do(req)
+	require.NoError(h.t, err)
+	defer res.Body.Close()
+
+	if out != nil {
+		require.NoError(h.t, json.NewDecoder(res.Body).Decode(out))
+	}
+
+	return res.StatusCode
+}
+
+// metrics returns the metrics endpoint response in prometheus text format.
+func (h *harness) metrics() string {
+	h.t.Helper()
+
+	res, err := h.server.Client().Get(h.server.URL + "/metrics")
+	require.NoError(h.t, err)
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(h.t, err)
+	require.Equal(h.t, http.StatusOK, res.StatusCode)
+
+	return string(body)
+}
+
+func (h *harness) match(id uint) *repository.Match {
+	h.t.Helper()
+
+	var match repository.Match
+	err := h.db.Preload("FootballApiFixtures").Where("id = ?", id).Take(&match).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil
+	}
+	require.NoError(h.t, err)
+
+	return &match
+}
+
+func (h *harness) subscriptions(matchID uint) []repository.Subscription {
+	h.t.Helper()
+
+	var subscriptions []repository.Subscription
+	require.NoError(h.t, h.db.Where("match_id = ?", matchID).Find(&subscriptions).Error)
+
+	return subscriptions
+}
+
+// waitForResultStatus waits until the match has the result status.
+func (h *harness) waitForResultStatus(matchID uint, status repository.ResultStatus) {
+	h.t.Helper()
+
+	require.Eventually(h.t, func() bool {
+		match := h.match(matchID)
+		return match != nil && match.ResultStatus == status
+	}, 10*time.Second, 50*time.Millisecond, "match %d doesn't have result status %s", matchID, status)
+}
+
+func hashAPIKey(key string) string {
+	h := hmac.New(sha512.New, []byte(secretKey))
+	h.Write([]byte(key))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,36 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealth(t *testing.T) {
+	h := newHarness(t, harnessOptions{pollingMaxRetries: 1})
+
+	for _, path := range []string{"/healthz", "/readyz"} {
+		res, err := h.server.Client().Get(h.server.URL + path)
+		require.NoError(t, err)
+
+		var body struct {
+			Status string `json:"status"`
+			Checks map[string]struct {
+				Status string `json:"status"`
+			} `json:"checks"`
+		}
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+		require.NoError(t, res.Body.Close())
+
+		assert.Equal(t, http.StatusOK, res.StatusCode, path)
+		assert.Equal(t, "ok", body.Status, path)
+		for name, check := range body.Checks {
+			assert.Equal(t, "ok", check.Status, "%s check of %s", name, path)
+		}
+	}
+}
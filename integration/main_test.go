@@ -0,0 +1,129 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/andrewshostak/result-service/config"
+)
+
+// pg is a database created for the test run. Migrations are applied by the app on the first connection.
+var pg config.PG
+
+// TestMain starts Postgres and creates a database for the run. An external Postgres (e.g. a local binary or a CI service)
+// is used when INTEGRATION_PG_HOST is set, otherwise embedded Postgres is started.
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	admin, stop, err := startPostgres()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to start postgres: %s\n", err)
+		return 1
+	}
+	defer stop()
+
+	pg = admin
+	pg.Database = fmt.Sprintf("result_service_it_%d", time.Now().UnixNano())
+	pg.MigrationsSource = "file://../migrations"
+
+	if err := execAdmin(admin, fmt.Sprintf("create database %s", pg.Database)); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create database: %s\n", err)
+		return 1
+	}
+	defer func() {
+		_ = execAdmin(admin, fmt.Sprintf("drop database if exists %s with (force)", pg.Database))
+	}()
+
+	return m.Run()
+}
+
+func startPostgres() (config.PG, func(), error) {
+	if host := os.Getenv("INTEGRATION_PG_HOST"); host != "" {
+		return config.PG{
+			Host:     host,
+			Port:     getEnv("INTEGRATION_PG_PORT", "5432"),
+			User:     getEnv("INTEGRATION_PG_USER", "postgres"),
+			Password: getEnv("INTEGRATION_PG_PASSWORD", "postgres"),
+			Database: "postgres",
+		}, func() {}, nil
+	}
+
+	port, err := freePort()
+	if err != nil {
+		return config.PG{}, nil, err
+	}
+
+	runtimePath, err := os.MkdirTemp("", "result-service-pg")
+	if err != nil {
+		return config.PG{}, nil, err
+	}
+
+	db := embeddedpostgres.NewDatabase(embeddedpostgres.DefaultConfig().
+		Port(uint32(port)).
+		Username("postgres").
+		Password("postgres").
+		Database("postgres").
+		RuntimePath(runtimePath).
+		StartTimeout(time.Minute).
+		Logger(os.Stderr))
+	if err := db.Start(); err != nil {
+		_ = os.RemoveAll(runtimePath)
+		return config.PG{}, nil, fmt.Errorf("failed to start embedded postgres: %w", err)
+	}
+
+	stop := func() {
+		_ = db.Stop()
+		_ = os.RemoveAll(runtimePath)
+	}
+
+	return config.PG{Host: "localhost", Port: fmt.Sprint(port), User: "postgres", Password: "postgres", Database: "postgres"}, stop, nil
+}
+
+func execAdmin(admin config.PG, query string) error {
+	dsn := fmt.Sprintf("host=%s user=%s password=%s port=%s database=%s sslmode=disable", admin.Host, admin.User, admin.Password, admin.Port, admin.Database)
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: gormlogger.Discard})
+	if err != nil {
+		return err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	return db.Exec(query).Error
+}
+
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+func getEnv(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
@@ -0,0 +1,198 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/andrewshostak/result-service/fakefootballapi"
+	"github.com/andrewshostak/result-service/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	fixtureID  = 1035000
+	homeTeamID = 33
+	awayTeamID = 34
+	homeAlias  = "Manchester United"
+	awayAlias  = "Newcastle"
+)
+
+type fixtureSetup struct {
+	kickOff  time.Time
+	timeline []fakefootballapi.TimelineStep
+}
+
+// setupFixture imports aliases of both teams and loads the fixture into the fake football-api.
+func (h *harness) setupFixture(setup fixtureSetup) {
+	h.t.Helper()
+
+	season := uint(setup.kickOff.Year())
+	if !setup.kickOff.After(time.Date(setup.kickOff.Year(), 6, 3, 0, 0, 0, 0, time.UTC)) {
+		season--
+	}
+
+	require.NoError(h.t, h.store.Load(fakefootballapi.Scenario{
+		Leagues: []fakefootballapi.ScenarioLeague{{
+			ID:      39,
+			Name:    "Premier League",
+			Country: "England",
+			Season:  season,
+			Teams:   []fakefootballapi.ScenarioTeam{{ID: homeTeamID, Name: homeAlias}, {ID: awayTeamID, Name: awayAlias}},
+		}},
+		Fixtures: []fakefootballapi.ScenarioFixture{{
+			ID:         fixtureID,
+			LeagueID:   39,
+			Season:     season,
+			Round:      "Regular Season - 9",
+			HomeTeamID: homeTeamID,
+			AwayTeamID: awayTeamID,
+			Date:       &setup.kickOff,
+			Timeline:   setup.timeline,
+		}},
+	}))
+
+	aliases := fmt.Sprintf(`[{"team_id":1,"football_api_team_id":%d,"aliases":[%q]},{"team_id":2,"football_api_team_id":%d,"aliases":[%q]}]`,
+		homeTeamID, homeAlias, awayTeamID, awayAlias)
+	require.Equal(h.t, http.StatusOK, h.do(http.MethodPost, "/v1/aliases/import?format=json", aliases, nil))
+}
+
+func (h *harness) createMatch(kickOff time.Time) uint {
+	h.t.Helper()
+
+	var response struct {
+		MatchID uint `json:"match_id"`
+	}
+	status := h.do(http.MethodPost, "/v1/matches", map[string]any{
+		"starts_at":  kickOff.Format(time.RFC3339),
+		"alias_home": homeAlias,
+		"alias_away": awayAlias,
+	}, &response)
+	require.Equal(h.t, http.StatusOK, status)
+	require.NotZero(h.t, response.MatchID)
+
+	return response.MatchID
+}
+
+func (h *harness) subscribe(matchID uint, key string) {
+	h.t.Helper()
+
+	status := h.do(http.MethodPost, "/v1/subscriptions", map[string]any{
+		"match_id":   matchID,
+		"url":        h.subscriber.server.URL + "/bets",
+		"secret_key": key,
+	}, nil)
+	require.Equal(h.t, http.StatusNoContent, status)
+}
+
+func elapsed(minute uint) *uint {
+	return &minute
+}
+
+var finishedTimeline = []fakefootballapi.TimelineStep{
+	{After: fakefootballapi.Duration(0), Status: "1H"},
+	{After: fakefootballapi.Duration(30 * time.Minute), Status: "1H", Elapsed: elapsed(30), Home: 1},
+	{After: fakefootballapi.Duration(47 * time.Minute), Status: "HT", Home: 1},
+	{After: fakefootballapi.Duration(62 * time.Minute), Status: "2H", Home: 1},
+	{After: fakefootballapi.Duration(100 * time.Minute), Status: "2H", Elapsed: elapsed(84), Home: 2, Away: 1},
+	{After: fakefootballapi.Duration(120 * time.Minute), Status: "FT", Home: 2, Away: 1},
+}
+
+func TestMatchResultLifecycle(t *testing.T) {
+	h := newHarness(t, harnessOptions{pollingMaxRetries: 3})
+
+	kickOff := h.clock.Now()
+	h.setupFixture(fixtureSetup{kickOff: kickOff, timeline: finishedTimeline})
+
+	matchID := h.createMatch(kickOff)
+	h.subscribe(matchID, "subscriber-key")
+
+	// the first attempt finds the second half in progress, so the result is polled with live interval
+	h.clock.Advance(115 * time.Minute)
+	assert.Equal(t, repository.Scheduled, h.match(matchID).ResultStatus)
+
+	h.clock.Advance(10 * time.Minute)
+	h.waitForResultStatus(matchID, repository.Successful)
+
+	match := h.match(matchID)
+	require.Len(t, match.FootballApiFixtures, 1)
+	assert.Equal(t, uint(fixtureID), match.FootballApiFixtures[0].ID)
+
+	// subscribers are notified right after the result is saved or by the notifier job on its next tick
+	h.clock.Advance(time.Minute)
+	require.Eventually(t, func() bool { return len(h.subscriber.Notifications()) == 1 }, 10*time.Second, 50*time.Millisecond)
+
+	notifications := h.subscriber.Notifications()
+	assert.Equal(t, "subscriber-key", notifications[0].Authorization)
+	assert.Equal(t, map[string]uint{"home": 2, "away": 1}, notifications[0].Body)
+
+	require.Eventually(t, func() bool {
+		subscriptions := h.subscriptions(matchID)
+		return len(subscriptions) == 1 && subscriptions[0].Status == repository.SuccessfulSub && subscriptions[0].NotifiedAt != nil
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// the delivered notification is removed from the outbox together with the subscription status update
+	var pending int64
+	require.NoError(t, h.db.Model(&repository.OutboxNotification{}).Where("match_id = ?", matchID).Count(&pending).Error)
+	assert.Zero(t, pending)
+
+	metrics := h.metrics()
+	assert.Contains(t, metrics, `result_service_matches{result_status="successful"} 1`)
+	assert.Contains(t, metrics, `result_service_polling_attempts_total{outcome="finished"} 1`)
+	assert.Contains(t, metrics, `result_service_subscription_notifications_total{outcome="successful"} 1`)
+	assert.Contains(t, metrics, `result_service_http_requests_total{method="POST",route="/v1/matches",status="200"} 1`)
+}
+
+func TestMatchResultCancelledByUnsubscribe(t *testing.T) {
+	h := newHarness(t, harnessOptions{pollingMaxRetries: 3})
+
+	kickOff := h.clock.Now()
+	h.setupFixture(fixtureSetup{kickOff: kickOff, timeline: finishedTimeline})
+
+	matchID := h.createMatch(kickOff)
+	h.subscribe(matchID, "subscriber-key")
+
+	query := url.Values{}
+	query.Set("starts_at", kickOff.Format(time.RFC3339))
+	query.Set("alias_home", homeAlias)
+	query.Set("alias_away", awayAlias)
+	query.Set("base_url", h.subscriber.server.URL)
+	query.Set("secret_key", "subscriber-key")
+	require.Equal(t, http.StatusNoContent, h.do(http.MethodDelete, "/v1/subscriptions?"+query.Encode(), nil, nil))
+
+	assert.Nil(t, h.match(matchID))
+
+	requests := h.apiRequests.Load()
+	h.clock.Advance(3 * time.Hour)
+	assert.Equal(t, requests, h.apiRequests.Load(), "football-api is polled after the match is deleted")
+}
+
+func TestMatchResultNotReceived(t *testing.T) {
+	h := newHarness(t, harnessOptions{pollingMaxRetries: 2})
+
+	// the fixture is not started (e.g. postponed without a status update)
+	kickOff := h.clock.Now()
+	h.setupFixture(fixtureSetup{kickOff: kickOff})
+
+	matchID := h.createMatch(kickOff)
+	h.subscribe(matchID, "subscriber-key")
+
+	h.clock.Advance(115 * time.Minute)
+	assert.Equal(t, repository.Scheduled, h.match(matchID).ResultStatus)
+
+	h.clock.Advance(30 * time.Minute)
+	h.waitForResultStatus(matchID, repository.Error)
+
+	require.NoError(t, h.app.NotifierService.NotifySubscribers(context.Background()))
+	assert.Empty(t, h.subscriber.Notifications())
+
+	subscriptions := h.subscriptions(matchID)
+	require.Len(t, subscriptions, 1)
+	assert.Equal(t, repository.PendingSub, subscriptions[0].Status)
+}
@@ -6,22 +6,81 @@ import (
 	"os"
 	"time"
 
+	"github.com/andrewshostak/result-service/config"
 	"github.com/rs/zerolog"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-func SetupLogger(writers ...io.Writer) *zerolog.Logger {
-	writers = append(writers, os.Stderr)
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// SetupLogger creates the root logger according to the config. Logs are written to stderr and, when a file is set,
+// to the rotated file in JSON format.
+func SetupLogger(cfg config.Log) (*zerolog.Logger, error) {
+	level, err := parseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	for component, componentLevel := range cfg.ComponentLevels {
+		if _, err := parseLevel(componentLevel); err != nil {
+			return nil, fmt.Errorf("component %s: %w", component, err)
+		}
+	}
+
+	var stderr io.Writer
+	switch cfg.Format {
+	case FormatJSON:
+		stderr = os.Stderr
+	case FormatConsole:
+		stderr = zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339}
+	default:
+		return nil, fmt.Errorf("unknown log format: %s", cfg.Format)
+	}
+
+	writers := []io.Writer{stderr}
+	if cfg.File != "" {
+		writers = append(writers, &lumberjack.Logger{
+			Filename:   cfg.File,
+			MaxSize:    cfg.FileMaxSizeMB,
+			MaxBackups: cfg.FileMaxBackups,
+			MaxAge:     cfg.FileMaxAgeDays,
+		})
+	}
+
 	zerolog.TimeFieldFormat = time.RFC3339Nano
-	logger := zerolog.New(zerolog.MultiLevelWriter(writers...)).With().Timestamp().Logger()
-	return &logger
+	logger := zerolog.New(zerolog.MultiLevelWriter(writers...)).Level(level).With().Timestamp().Logger()
+
+	return &logger, nil
 }
 
-func GetLogFile() (*os.File, error) {
-	filename := "app.log"
-	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open %s file to write logs: %w", filename, err)
+// Component returns a logger which adds component field to log lines. Its level is taken from levels
+// when the component is there, otherwise the level of the parent logger is kept.
+func Component(logger *zerolog.Logger, name string, levels map[string]string) *zerolog.Logger {
+	componentLogger := logger.With().Str("component", name).Logger()
+
+	if levelName, ok := levels[name]; ok {
+		if level, err := parseLevel(levelName); err == nil {
+			componentLogger = componentLogger.Level(level)
+		}
 	}
 
-	return file, nil
+	return &componentLogger
+}
+
+func parseLevel(level string) (zerolog.Level, error) {
+	switch level {
+	case "debug":
+		return zerolog.DebugLevel, nil
+	case "info":
+		return zerolog.InfoLevel, nil
+	case "warn":
+		return zerolog.WarnLevel, nil
+	case "error":
+		return zerolog.ErrorLevel, nil
+	default:
+		return zerolog.NoLevel, fmt.Errorf("unknown log level: %s", level)
+	}
 }
@@ -0,0 +1,117 @@
+package logger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andrewshostak/result-service/config"
+	"github.com/andrewshostak/result-service/logger"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupLogger(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           config.Log
+		expectedLevel zerolog.Level
+		expectedErr   string
+	}{
+		{
+			name:          "it should create json logger with the level",
+			cfg:           config.Log{Level: "debug", Format: logger.FormatJSON},
+			expectedLevel: zerolog.DebugLevel,
+		},
+		{
+			name:          "it should create console logger with the level",
+			cfg:           config.Log{Level: "warn", Format: logger.FormatConsole},
+			expectedLevel: zerolog.WarnLevel,
+		},
+		{
+			name:          "it should accept valid component levels",
+			cfg:           config.Log{Level: "info", Format: logger.FormatJSON, ComponentLevels: map[string]string{"match": "debug", "football-api": "error"}},
+			expectedLevel: zerolog.InfoLevel,
+		},
+		{
+			name:        "it should return error when the level is invalid",
+			cfg:         config.Log{Level: "verbose", Format: logger.FormatJSON},
+			expectedErr: "unknown log level: verbose",
+		},
+		{
+			name:        "it should return error when the level of a component is invalid",
+			cfg:         config.Log{Level: "info", Format: logger.FormatJSON, ComponentLevels: map[string]string{"match": "trace"}},
+			expectedErr: "component match: unknown log level: trace",
+		},
+		{
+			name:        "it should return error when the format is unknown",
+			cfg:         config.Log{Level: "info", Format: "xml"},
+			expectedErr: "unknown log format: xml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := logger.SetupLogger(tt.cfg)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, l)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedLevel, l.GetLevel())
+		})
+	}
+
+	t.Run("it should write json lines to the file in console format too", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "service.log")
+
+		l, err := logger.SetupLogger(config.Log{Level: "info", Format: logger.FormatConsole, File: file, FileMaxSizeMB: 1})
+		require.NoError(t, err)
+
+		l.Debug().Msg("skipped")
+		l.Info().Str("match_id", "7").Msg("match saved")
+
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(content, &line))
+		assert.Equal(t, "info", line["level"])
+		assert.Equal(t, "7", line["match_id"])
+		assert.Equal(t, "match saved", line["message"])
+		assert.Contains(t, line, "time")
+	})
+}
+
+func TestComponent(t *testing.T) {
+	levels := map[string]string{"match": "debug", "football-api": "error", "alias": "verbose"}
+
+	tests := []struct {
+		name          string
+		component     string
+		expectedLevel zerolog.Level
+	}{
+		{name: "it should lower the level of the component", component: "match", expectedLevel: zerolog.DebugLevel},
+		{name: "it should raise the level of the component", component: "football-api", expectedLevel: zerolog.ErrorLevel},
+		{name: "it should keep the parent level when the component is not overridden", component: "notifier", expectedLevel: zerolog.InfoLevel},
+		{name: "it should keep the parent level when the level of the component is invalid", component: "alias", expectedLevel: zerolog.InfoLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			root := zerolog.New(&logs).Level(zerolog.InfoLevel)
+
+			l := logger.Component(&root, tt.component, levels)
+			assert.Equal(t, tt.expectedLevel, l.GetLevel())
+
+			l.WithLevel(tt.expectedLevel).Msg("written")
+			assert.JSONEq(t, `{"level":"`+tt.expectedLevel.String()+`","component":"`+tt.component+`","message":"written"}`, logs.String())
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+)
+
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx which carries the id of the request being handled.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// WithRequestID returns the logger which adds request id to log lines when ctx carries one.
+// The component and the level of the logger are kept. Background jobs don't have a request id, so they log with the logger as is.
+func WithRequestID[L any](ctx context.Context, logger L) L {
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+	if !ok {
+		return logger
+	}
+
+	zerologLogger, ok := any(logger).(*zerolog.Logger)
+	if !ok {
+		return logger
+	}
+
+	requestLogger := zerologLogger.With().Str("request_id", requestID).Logger()
+	if l, ok := any(&requestLogger).(L); ok {
+		return l
+	}
+
+	return logger
+}
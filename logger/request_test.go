@@ -0,0 +1,39 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/andrewshostak/result-service/logger"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+type eventLogger interface {
+	Debug() *zerolog.Event
+	Info() *zerolog.Event
+}
+
+func TestWithRequestID(t *testing.T) {
+	t.Run("it should add request id and keep the component and the level of the logger", func(t *testing.T) {
+		var logs bytes.Buffer
+		root := zerolog.New(&logs)
+		component := logger.Component(&root, "subscription", map[string]string{"subscription": "info"})
+
+		ctx := logger.ContextWithRequestID(context.Background(), "abc-123")
+		requestLogger := logger.WithRequestID[eventLogger](ctx, component)
+
+		requestLogger.Debug().Msg("skipped")
+		requestLogger.Info().Msg("written")
+
+		assert.Equal(t, `{"level":"info","component":"subscription","request_id":"abc-123","message":"written"}`+"\n", logs.String())
+	})
+
+	t.Run("it should return the logger as is when ctx doesn't carry request id", func(t *testing.T) {
+		var logs bytes.Buffer
+		root := zerolog.New(&logs)
+
+		assert.Same(t, &root, logger.WithRequestID(context.Background(), &root))
+	})
+}
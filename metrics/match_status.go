@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const matchStatusQueryTimeout = 5 * time.Second
+
+type MatchStatusCounter interface {
+	// CountByResultStatus returns number of matches by result status including statuses without matches.
+	CountByResultStatus(ctx context.Context) (map[string]int64, error)
+}
+
+type matchStatusCollector struct {
+	counter MatchStatusCounter
+	desc    *prometheus.Desc
+}
+
+func newMatchStatusCollector(counter MatchStatusCounter) *matchStatusCollector {
+	return &matchStatusCollector{
+		counter: counter,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "matches"),
+			"Number of matches by result status.",
+			[]string{"result_status"},
+			nil,
+		),
+	}
+}
+
+func (c *matchStatusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *matchStatusCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), matchStatusQueryTimeout)
+	defer cancel()
+
+	counts, err := c.counter.CountByResultStatus(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+
+	for status, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(count), status)
+	}
+}
@@ -0,0 +1,131 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "result_service"
+
+// Metrics keeps collectors of the service in its own registry, which is exposed by Handler.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests        *prometheus.CounterVec
+	httpRequestDuration *prometheus.HistogramVec
+	footballAPIRequests *prometheus.CounterVec
+	pollingAttempts     *prometheus.CounterVec
+	notifications       *prometheus.CounterVec
+	notifierRunDuration prometheus.Histogram
+	notifierRunFailures prometheus.Counter
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Number of handled http requests by route and status code.",
+		}, []string{"method", "route", "status"}),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Duration of handled http requests by route.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		footballAPIRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "football_api_requests_total",
+			Help:      "Number of football-api request attempts by path, outcome and status code. Status code is empty when there is no response.",
+		}, []string{"path", "outcome", "status_code"}),
+		pollingAttempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "polling_attempts_total",
+			Help:      "Number of attempts to get a match result by outcome.",
+		}, []string{"outcome"}),
+		notifications: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "subscription_notifications_total",
+			Help:      "Number of subscriber notifications by outcome.",
+		}, []string{"outcome"}),
+		notifierRunDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "notifier_run_duration_seconds",
+			Help:      "Duration of a notifier run which notifies all pending subscribers.",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.5, 1, 2.5, 5, 10, 30, 60},
+		}),
+		notifierRunFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "notifier_run_failures_total",
+			Help:      "Number of notifier runs which failed, e.g. because the database is unavailable.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpRequestDuration,
+		m.footballAPIRequests,
+		m.pollingAttempts,
+		m.notifications,
+		m.notifierRunDuration,
+		m.notifierRunFailures,
+	)
+
+	return m
+}
+
+// Handler serves metrics in prometheus text format. Metrics which failed to be collected (e.g. when the database is down)
+// are skipped, so the rest is still exposed.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry, ErrorHandling: promhttp.ContinueOnError})
+}
+
+// RegisterDB exposes connection pool stats of the database.
+func (m *Metrics) RegisterDB(db *sql.DB, name string) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// RegisterMatchStatuses exposes number of matches by result status, counted on each scrape.
+func (m *Metrics) RegisterMatchStatuses(counter MatchStatusCounter) {
+	m.registry.MustRegister(newMatchStatusCollector(counter))
+}
+
+func (m *Metrics) ObserveHTTPRequest(method string, route string, status int, duration time.Duration) {
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpRequestDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// FootballAPIRequest counts a request attempt. Zero status code means there was no response.
+func (m *Metrics) FootballAPIRequest(path string, outcome string, statusCode int) {
+	code := ""
+	if statusCode != 0 {
+		code = strconv.Itoa(statusCode)
+	}
+
+	m.footballAPIRequests.WithLabelValues(path, outcome, code).Inc()
+}
+
+func (m *Metrics) PollingAttempt(outcome string) {
+	m.pollingAttempts.WithLabelValues(outcome).Inc()
+}
+
+func (m *Metrics) Notification(outcome string) {
+	m.notifications.WithLabelValues(outcome).Inc()
+}
+
+func (m *Metrics) ObserveNotifierRun(duration time.Duration) {
+	m.notifierRunDuration.Observe(duration.Seconds())
+}
+
+func (m *Metrics) NotifierRunFailed() {
+	m.notifierRunFailures.Inc()
+}
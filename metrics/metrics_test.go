@@ -0,0 +1,60 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andrewshostak/result-service/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+type matchStatusCounter struct {
+	counts map[string]int64
+	err    error
+}
+
+func (c matchStatusCounter) CountByResultStatus(_ context.Context) (map[string]int64, error) {
+	return c.counts, c.err
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	scrape := func(m *metrics.Metrics) (int, string) {
+		rec := httptest.NewRecorder()
+		m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		return rec.Code, rec.Body.String()
+	}
+
+	t.Run("it should expose recorded metrics and matches by result status", func(t *testing.T) {
+		m := metrics.New()
+		m.RegisterMatchStatuses(matchStatusCounter{counts: map[string]int64{"scheduled": 3, "error": 0}})
+
+		m.FootballAPIRequest("/v3/fixtures", "success", http.StatusOK)
+		m.FootballAPIRequest("/v3/fixtures", "circuit_open", 0)
+		m.PollingAttempt("finished")
+		m.Notification("successful")
+
+		code, body := scrape(m)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, `result_service_football_api_requests_total{outcome="success",path="/v3/fixtures",status_code="200"} 1`)
+		assert.Contains(t, body, `result_service_football_api_requests_total{outcome="circuit_open",path="/v3/fixtures",status_code=""} 1`)
+		assert.Contains(t, body, `result_service_polling_attempts_total{outcome="finished"} 1`)
+		assert.Contains(t, body, `result_service_subscription_notifications_total{outcome="successful"} 1`)
+		assert.Contains(t, body, `result_service_matches{result_status="scheduled"} 3`)
+		assert.Contains(t, body, `result_service_matches{result_status="error"} 0`)
+	})
+
+	t.Run("it should expose other metrics when matches can't be counted", func(t *testing.T) {
+		m := metrics.New()
+		m.RegisterMatchStatuses(matchStatusCounter{err: errors.New("connection refused")})
+		m.PollingAttempt("error")
+
+		code, body := scrape(m)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, `result_service_polling_attempts_total{outcome="error"} 1`)
+		assert.NotContains(t, body, "result_service_matches{")
+	})
+}
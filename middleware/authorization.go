@@ -15,7 +15,7 @@ func Authorization(hashedAPIKeys []string, secret string) gin.HandlerFunc {
 		apiKey := c.GetHeader(authorization)
 
 		if !isValidAPIKey(apiKey, hashedAPIKeys, secret) {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid api key"})
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorBody(c, "invalid api key"))
 			return
 		}
 
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	loggerinternal "github.com/andrewshostak/result-service/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+
+	requestIDKey       = "request_id"
+	maxRequestIDLength = 128
+)
+
+// RequestLogger accepts X-Request-ID header or generates a new id, returns it in the response header,
+// and puts the id into the request context, so services and clients add it to log lines of their components.
+// Each request is logged when it's handled. Trace id is added when the request is traced, so it has to run after the tracing middleware.
+func RequestLogger(logger *zerolog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		requestID := c.GetHeader(RequestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = newRequestID()
+		}
+
+		c.Set(requestIDKey, requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		logContext := logger.With().Str(requestIDKey, requestID)
+		if spanContext := trace.SpanContextFromContext(c.Request.Context()); spanContext.IsValid() {
+			logContext = logContext.Str("trace_id", spanContext.TraceID().String())
+		}
+
+		requestLogger := logContext.Logger()
+		c.Request = c.Request.WithContext(loggerinternal.ContextWithRequestID(c.Request.Context(), requestID))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		event := requestLogger.Info()
+		switch {
+		case status >= http.StatusInternalServerError:
+			event = requestLogger.Error()
+		case status >= http.StatusBadRequest:
+			event = requestLogger.Warn()
+		}
+
+		if len(c.Errors) > 0 {
+			event = event.Str("errors", c.Errors.String())
+		}
+
+		event.
+			Str("method", c.Request.Method).
+			Str("path", c.Request.URL.Path).
+			Str("route", c.FullPath()).
+			Int("status", status).
+			Dur("latency", time.Since(start)).
+			Str("client_ip", c.ClientIP()).
+			Int("size", c.Writer.Size()).
+			Msg("request handled")
+	}
+}
+
+// RequestID returns id of the request set by RequestLogger.
+func RequestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+// ErrorBody is a response body of a failed request. The request id helps to find log lines of the request.
+func ErrorBody(c *gin.Context, message string) gin.H {
+	return gin.H{"error": message, requestIDKey: RequestID(c)}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// isValidRequestID accepts only printable ascii ids of a limited length, so a client can't inject anything into logs.
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range id {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,72 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	loggerinternal "github.com/andrewshostak/result-service/logger"
+	"github.com/andrewshostak/result-service/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestLogger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setup := func() (*gin.Engine, *bytes.Buffer) {
+		var logs bytes.Buffer
+		logger := zerolog.New(&logs)
+		serviceLogger := zerolog.New(&logs).With().Str("component", "subscription").Logger()
+
+		r := gin.New()
+		r.Use(middleware.RequestLogger(&logger))
+		r.GET("/fail", func(c *gin.Context) {
+			loggerinternal.WithRequestID(c.Request.Context(), &serviceLogger).Info().Msg("from handler")
+			c.JSON(http.StatusBadRequest, middleware.ErrorBody(c, "bad request"))
+		})
+
+		return r, &logs
+	}
+
+	t.Run("it accepts request id from the header and returns it in the response and the error body", func(t *testing.T) {
+		r, logs := setup()
+
+		req := httptest.NewRequest(http.MethodGet, "/fail", nil)
+		req.Header.Set(middleware.RequestIDHeader, "abc-123")
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, "abc-123", rec.Header().Get(middleware.RequestIDHeader))
+
+		var body map[string]string
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, map[string]string{"error": "bad request", "request_id": "abc-123"}, body)
+
+		lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+		assert.Len(t, lines, 2)
+		for _, line := range lines {
+			assert.Contains(t, line, `"request_id":"abc-123"`)
+		}
+		assert.Contains(t, lines[0], `"component":"subscription"`)
+		assert.Contains(t, lines[1], `"level":"warn"`)
+		assert.Contains(t, lines[1], `"status":400`)
+	})
+
+	t.Run("it generates request id when the header is missing or invalid", func(t *testing.T) {
+		for _, header := range []string{"", "id with spaces", strings.Repeat("a", 129)} {
+			r, _ := setup()
+
+			req := httptest.NewRequest(http.MethodGet, "/fail", nil)
+			req.Header.Set(middleware.RequestIDHeader, header)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Len(t, rec.Header().Get(middleware.RequestIDHeader), 32)
+		}
+	})
+}
@@ -0,0 +1,5 @@
+begin;
+
+alter table football_api_fixtures drop column if exists provider;
+
+commit;
@@ -0,0 +1,5 @@
+begin;
+
+alter table football_api_fixtures add column if not exists provider varchar(32) not null default 'api-football';
+
+commit;
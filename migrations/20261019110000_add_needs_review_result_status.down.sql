@@ -0,0 +1,12 @@
+begin;
+
+update matches set result_status = 'error' where result_status = 'needs_review';
+
+alter type result_status rename to result_status_old;
+create type result_status as enum ('not_scheduled', 'scheduled', 'scheduling_error', 'error', 'successful');
+alter table matches alter column result_status drop default;
+alter table matches alter column result_status type result_status using result_status::text::result_status;
+alter table matches alter column result_status set default 'not_scheduled';
+drop type result_status_old;
+
+commit;
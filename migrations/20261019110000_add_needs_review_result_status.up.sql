@@ -0,0 +1 @@
+alter type result_status add value if not exists 'needs_review';
@@ -0,0 +1,6 @@
+begin;
+
+alter table matches drop column if exists span_id;
+alter table matches drop column if exists trace_id;
+
+commit;
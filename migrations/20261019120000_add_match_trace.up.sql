@@ -0,0 +1,6 @@
+begin;
+
+alter table matches add column if not exists trace_id varchar(32);
+alter table matches add column if not exists span_id varchar(16);
+
+commit;
@@ -0,0 +1,22 @@
+begin;
+
+create table if not exists notification_outbox (
+    id bigserial primary key,
+    subscription_id bigint not null unique,
+    match_id bigint not null,
+    created_at timestamp not null default now(),
+    locked_until timestamp,
+    foreign key (subscription_id) references subscriptions (id) on update cascade on delete cascade,
+    foreign key (match_id) references matches (id) on update cascade on delete cascade
+);
+
+create index if not exists notification_outbox_match_id_idx on notification_outbox (match_id);
+
+insert into notification_outbox (subscription_id, match_id)
+select subscriptions.id, subscriptions.match_id
+from subscriptions
+join matches on matches.id = subscriptions.match_id
+where subscriptions.status = 'pending' and matches.result_status = 'successful'
+on conflict (subscription_id) do nothing;
+
+commit;
@@ -0,0 +1,5 @@
+begin;
+
+alter table notification_outbox alter column locked_until type timestamp using locked_until at time zone 'UTC';
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+-- leases were written as utc wall-clock time
+alter table notification_outbox alter column locked_until type timestamptz using locked_until at time zone 'UTC';
+
+commit;
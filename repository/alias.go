@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/andrewshostak/result-service/errs"
@@ -31,6 +32,31 @@ func (r *AliasRepository) Find(ctx context.Context, alias string) (*Alias, error
 	return &a, nil
 }
 
+func (r *AliasRepository) FindByFootballAPITeamID(ctx context.Context, footballAPITeamID uint) (*Alias, error) {
+	var a Alias
+
+	result := r.db.WithContext(ctx).Joins("FootballApiTeam").Where(`"FootballApiTeam"."id" = ?`, footballAPITeamID).First(&a)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("alias of football api team %d not found: %w", footballAPITeamID, errs.AliasNotFoundError{Message: result.Error.Error()})
+		}
+
+		return nil, result.Error
+	}
+
+	return &a, nil
+}
+
+func (r *AliasRepository) Create(ctx context.Context, teamID uint, alias string) (*Alias, error) {
+	a := Alias{TeamID: teamID, Alias: alias}
+	result := r.db.WithContext(ctx).Create(&a)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &a, nil
+}
+
 func (r *AliasRepository) SaveInTrx(ctx context.Context, alias string, footballAPITeamID uint) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		team := Team{}
@@ -51,6 +77,51 @@ func (r *AliasRepository) SaveInTrx(ctx context.Context, alias string, footballA
 	})
 }
 
+// ImportInTrx creates teams, aliases and football api teams which don't exist yet.
+// A team is matched by football api team id or by any of its aliases. When there are conflicts
+// nothing is saved, and the result is returned together with AliasImportConflictError.
+func (r *AliasRepository) ImportInTrx(ctx context.Context, teams []TeamImport) (*ImportResult, error) {
+	var result ImportResult
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range teams {
+			if err := importTeam(tx, teams[i], &result); err != nil {
+				return err
+			}
+		}
+
+		if len(result.Conflicts) > 0 {
+			return errs.AliasImportConflictError{Message: fmt.Sprintf("import has %d conflict(s)", len(result.Conflicts))}
+		}
+
+		return nil
+	})
+	if err != nil {
+		if errors.As(err, &errs.AliasImportConflictError{}) {
+			return &ImportResult{Conflicts: result.Conflicts}, err
+		}
+
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (r *AliasRepository) ListTeams(ctx context.Context) ([]Team, error) {
+	var teams []Team
+	result := r.db.WithContext(ctx).
+		Preload("Aliases", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload("FootballApiTeam").
+		Order("id").
+		Find(&teams)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return teams, nil
+}
+
 func (r *AliasRepository) Search(ctx context.Context, alias string) ([]Alias, error) {
 	var aliases []Alias
 	result := r.db.WithContext(ctx).Where("alias ILIKE ?", "%"+alias+"%").Limit(10).Find(&aliases)
@@ -61,3 +132,95 @@ func (r *AliasRepository) Search(ctx context.Context, alias string) ([]Alias, er
 
 	return aliases, nil
 }
+
+func importTeam(tx *gorm.DB, team TeamImport, result *ImportResult) error {
+	var teamID uint
+	conflicts := make([]ImportConflict, 0)
+
+	if team.FootballAPITeamID != nil {
+		var footballAPITeam FootballApiTeam
+		err := tx.Where("id = ?", *team.FootballAPITeamID).Take(&footballAPITeam).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to find football api team %d: %w", *team.FootballAPITeamID, err)
+		}
+
+		teamID = footballAPITeam.TeamID
+	}
+
+	toCreate := make([]string, 0, len(team.Aliases))
+	for _, alias := range team.Aliases {
+		var existing Alias
+		err := tx.Where("LOWER(alias) = LOWER(?)", alias).Take(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			toCreate = append(toCreate, alias)
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to find alias %s: %w", alias, err)
+		}
+
+		if teamID == 0 {
+			teamID = existing.TeamID
+			continue
+		}
+
+		if existing.TeamID != teamID {
+			conflicts = append(conflicts, ImportConflict{
+				SourceTeamID: team.SourceTeamID,
+				Alias:        alias,
+				Reason:       fmt.Sprintf("alias belongs to team %d, but team %d is expected", existing.TeamID, teamID),
+			})
+		}
+	}
+
+	var footballAPITeamExists bool
+	if teamID != 0 && team.FootballAPITeamID != nil {
+		var footballAPITeam FootballApiTeam
+		err := tx.Where("team_id = ?", teamID).Take(&footballAPITeam).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to find football api team of team %d: %w", teamID, err)
+		}
+
+		footballAPITeamExists = err == nil
+		if footballAPITeamExists && footballAPITeam.ID != *team.FootballAPITeamID {
+			conflicts = append(conflicts, ImportConflict{
+				SourceTeamID: team.SourceTeamID,
+				Reason:       fmt.Sprintf("team %d is linked to football api team %d instead of %d", teamID, footballAPITeam.ID, *team.FootballAPITeamID),
+			})
+		}
+	}
+
+	if len(conflicts) > 0 {
+		result.Conflicts = append(result.Conflicts, conflicts...)
+		return nil
+	}
+
+	if teamID == 0 {
+		created := Team{}
+		if err := tx.Create(&created).Error; err != nil {
+			return fmt.Errorf("failed to create team: %w", err)
+		}
+
+		teamID = created.ID
+		result.CreatedTeams++
+	}
+
+	for _, alias := range toCreate {
+		if err := tx.Create(&Alias{TeamID: teamID, Alias: alias}).Error; err != nil {
+			return fmt.Errorf("failed to create alias %s: %w", alias, err)
+		}
+
+		result.CreatedAliases++
+	}
+
+	if team.FootballAPITeamID != nil && !footballAPITeamExists {
+		if err := tx.Create(&FootballApiTeam{ID: *team.FootballAPITeamID, TeamID: teamID}).Error; err != nil {
+			return fmt.Errorf("failed to create football api team %d: %w", *team.FootballAPITeamID, err)
+		}
+
+		result.CreatedFootballAPITeams++
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/andrewshostak/result-service/errs"
+	"github.com/andrewshostak/result-service/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAliasRepository_ImportInTrx(t *testing.T) {
+	ctx := context.Background()
+	footballAPITeamID := uint(42)
+
+	findFootballAPITeam := regexp.QuoteMeta(`SELECT * FROM "football_api_teams" WHERE id = $1 LIMIT 1`)
+	findFootballAPITeamByTeam := regexp.QuoteMeta(`SELECT * FROM "football_api_teams" WHERE team_id = $1 LIMIT 1`)
+	findAlias := regexp.QuoteMeta(`SELECT * FROM "aliases" WHERE LOWER(alias) = LOWER($1) LIMIT 1`)
+
+	footballAPITeamRows := func(id uint, teamID uint) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "team_id"}).AddRow(id, teamID)
+	}
+	aliasRows := func(id uint, teamID uint, alias string) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "team_id", "alias"}).AddRow(id, teamID, alias)
+	}
+
+	t.Run("it should return conflict when alias belongs to another team than the football api team", func(t *testing.T) {
+		db, mock := newMockDB(t)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(findFootballAPITeam).WithArgs(footballAPITeamID).WillReturnRows(footballAPITeamRows(footballAPITeamID, 1))
+		mock.ExpectQuery(findAlias).WithArgs("Arsenal").WillReturnRows(aliasRows(10, 2, "Arsenal"))
+		mock.ExpectQuery(findFootballAPITeamByTeam).WithArgs(1).WillReturnRows(footballAPITeamRows(footballAPITeamID, 1))
+		mock.ExpectRollback()
+
+		result, err := repository.NewAliasRepository(db).ImportInTrx(ctx, []repository.TeamImport{
+			{SourceTeamID: 7, FootballAPITeamID: &footballAPITeamID, Aliases: []string{"Arsenal"}},
+		})
+
+		assert.ErrorAs(t, err, &errs.AliasImportConflictError{})
+		assert.Equal(t, []repository.ImportConflict{
+			{SourceTeamID: 7, Alias: "Arsenal", Reason: "alias belongs to team 2, but team 1 is expected"},
+		}, result.Conflicts)
+	})
+
+	t.Run("it should return conflict when aliases of the team belong to different teams", func(t *testing.T) {
+		db, mock := newMockDB(t)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(findAlias).WithArgs("Man United").WillReturnRows(aliasRows(10, 3, "Man United"))
+		mock.ExpectQuery(findAlias).WithArgs("Man City").WillReturnRows(aliasRows(11, 4, "Man City"))
+		mock.ExpectRollback()
+
+		result, err := repository.NewAliasRepository(db).ImportInTrx(ctx, []repository.TeamImport{
+			{SourceTeamID: 8, Aliases: []string{"Man United", "Man City"}},
+		})
+
+		assert.ErrorAs(t, err, &errs.AliasImportConflictError{})
+		assert.Equal(t, []repository.ImportConflict{
+			{SourceTeamID: 8, Alias: "Man City", Reason: "alias belongs to team 4, but team 3 is expected"},
+		}, result.Conflicts)
+	})
+
+	t.Run("it should return conflict when the team is linked to another football api team", func(t *testing.T) {
+		db, mock := newMockDB(t)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(findFootballAPITeam).WithArgs(footballAPITeamID).WillReturnRows(sqlmock.NewRows([]string{"id", "team_id"}))
+		mock.ExpectQuery(findAlias).WithArgs("Chelsea").WillReturnRows(aliasRows(12, 5, "Chelsea"))
+		mock.ExpectQuery(findFootballAPITeamByTeam).WithArgs(5).WillReturnRows(footballAPITeamRows(49, 5))
+		mock.ExpectRollback()
+
+		result, err := repository.NewAliasRepository(db).ImportInTrx(ctx, []repository.TeamImport{
+			{SourceTeamID: 9, FootballAPITeamID: &footballAPITeamID, Aliases: []string{"Chelsea"}},
+		})
+
+		assert.ErrorAs(t, err, &errs.AliasImportConflictError{})
+		assert.Equal(t, []repository.ImportConflict{
+			{SourceTeamID: 9, Reason: "team 5 is linked to football api team 49 instead of 42"},
+		}, result.Conflicts)
+	})
+
+	t.Run("it should save nothing when only some of the teams have conflicts", func(t *testing.T) {
+		db, mock := newMockDB(t)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(findAlias).WithArgs("Luton").WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "alias"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "teams"`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(20))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "aliases"`)).WithArgs(20, "Luton").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(30))
+		mock.ExpectQuery(findAlias).WithArgs("Spurs").WillReturnRows(aliasRows(13, 6, "Spurs"))
+		mock.ExpectQuery(findAlias).WithArgs("Arsenal").WillReturnRows(aliasRows(10, 2, "Arsenal"))
+		mock.ExpectRollback()
+
+		result, err := repository.NewAliasRepository(db).ImportInTrx(ctx, []repository.TeamImport{
+			{SourceTeamID: 1, Aliases: []string{"Luton"}},
+			{SourceTeamID: 2, Aliases: []string{"Spurs", "Arsenal"}},
+		})
+
+		assert.ErrorAs(t, err, &errs.AliasImportConflictError{})
+		assert.Equal(t, &repository.ImportResult{Conflicts: []repository.ImportConflict{
+			{SourceTeamID: 2, Alias: "Arsenal", Reason: "alias belongs to team 2, but team 6 is expected"},
+		}}, result)
+	})
+
+	t.Run("it should add new aliases and football api team to the existing team", func(t *testing.T) {
+		db, mock := newMockDB(t)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(findFootballAPITeam).WithArgs(footballAPITeamID).WillReturnRows(sqlmock.NewRows([]string{"id", "team_id"}))
+		mock.ExpectQuery(findAlias).WithArgs("Wolves").WillReturnRows(aliasRows(14, 7, "Wolves"))
+		mock.ExpectQuery(findAlias).WithArgs("Wolverhampton").WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "alias"}))
+		mock.ExpectQuery(findFootballAPITeamByTeam).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"id", "team_id"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "aliases"`)).WithArgs(7, "Wolverhampton").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(31))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "football_api_teams"`)).WithArgs(7, footballAPITeamID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(footballAPITeamID))
+		mock.ExpectCommit()
+
+		result, err := repository.NewAliasRepository(db).ImportInTrx(ctx, []repository.TeamImport{
+			{SourceTeamID: 3, FootballAPITeamID: &footballAPITeamID, Aliases: []string{"Wolves", "Wolverhampton"}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &repository.ImportResult{CreatedAliases: 1, CreatedFootballAPITeams: 1}, result)
+	})
+}
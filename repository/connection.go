@@ -15,15 +15,20 @@ import (
 	"gorm.io/gorm/logger"
 )
 
-func EstablishDatabaseConnection(cfg config.Config) *gorm.DB {
-	connectionParams := fmt.Sprintf(
+// ConnectionString returns connection params of the database. It is also used by connections outside the pool, e.g. to LISTEN.
+func ConnectionString(cfg config.PG) string {
+	return fmt.Sprintf(
 		"host=%s user=%s password=%s port=%s database=%s sslmode=disable",
-		cfg.PG.Host,
-		cfg.PG.User,
-		cfg.PG.Password,
-		cfg.PG.Port,
-		cfg.PG.Database,
+		cfg.Host,
+		cfg.User,
+		cfg.Password,
+		cfg.Port,
+		cfg.Database,
 	)
+}
+
+func EstablishDatabaseConnection(cfg config.Config) *gorm.DB {
+	connectionParams := ConnectionString(cfg.PG)
 
 	customLogger := logger.New(
 		log.New(os.Stdout, "\r\n", log.LstdFlags),
@@ -52,7 +57,7 @@ func EstablishDatabaseConnection(cfg config.Config) *gorm.DB {
 	sqlDb.SetMaxOpenConns(2)
 
 	driver, err := migratepg.WithInstance(sqlDb, &migratepg.Config{})
-	m, err := migrate.NewWithDatabaseInstance("file://./migrations", cfg.PG.Database, driver)
+	m, err := migrate.NewWithDatabaseInstance(cfg.PG.MigrationsSource, cfg.PG.Database, driver)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4/source"
+	"gorm.io/gorm"
+)
+
+type HealthRepository struct {
+	db *gorm.DB
+}
+
+func NewHealthRepository(db *gorm.DB) *HealthRepository {
+	return &HealthRepository{db: db}
+}
+
+func (r *HealthRepository) Ping(ctx context.Context) error {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.PingContext(ctx)
+}
+
+// MigrationVersion returns the version applied by golang-migrate.
+func (r *HealthRepository) MigrationVersion(ctx context.Context) (*MigrationVersion, error) {
+	var version MigrationVersion
+	result := r.db.WithContext(ctx).Raw("select version, dirty from schema_migrations limit 1").Scan(&version)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, errors.New("migrations are not applied")
+	}
+
+	return &version, nil
+}
+
+// LatestMigrationVersion returns the version of the last migration in the golang-migrate source.
+func LatestMigrationVersion(sourceURL string) (uint, error) {
+	src, err := source.Open(sourceURL)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open migrations source: %w", err)
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the first migration: %w", err)
+	}
+
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+
+		if err != nil {
+			return 0, fmt.Errorf("failed to read the migration after %d: %w", version, err)
+		}
+
+		version = next
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/andrewshostak/result-service/errs"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type MatchRepository struct {
@@ -56,6 +57,35 @@ func (r *MatchRepository) List(ctx context.Context, resultStatus ResultStatus) (
 	return matches, nil
 }
 
+// CountByResultStatus returns number of matches by result status. Statuses without matches have zero count.
+func (r *MatchRepository) CountByResultStatus(ctx context.Context) (map[string]int64, error) {
+	var rows []struct {
+		ResultStatus ResultStatus
+		Count        int64
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&Match{}).
+		Select("result_status, count(*) as count").
+		Group("result_status").
+		Scan(&rows)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	counts := make(map[string]int64, len(ResultStatuses))
+	for _, status := range ResultStatuses {
+		counts[string(status)] = 0
+	}
+
+	for _, row := range rows {
+		counts[string(row.ResultStatus)] = row.Count
+	}
+
+	return counts, nil
+}
+
 func (r *MatchRepository) One(ctx context.Context, search Match) (*Match, error) {
 	var match Match
 
@@ -102,3 +132,35 @@ func (r *MatchRepository) Update(ctx context.Context, id uint, resultStatus Resu
 
 	return &match, nil
 }
+
+// SaveResultInTrx saves the fixture data and the result status of the match. Subscribers of the match with successful
+// result are added to the notification outbox in the same transaction, so a saved result is never left without them.
+func (r *MatchRepository) SaveResultInTrx(ctx context.Context, matchResult MatchResult) error {
+	dataAsJson, err := toJsonB(matchResult.Data)
+	if err != nil {
+		return fmt.Errorf("failed to create jsonb data: %w", err)
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// the lock waits for subscriptions being created to the match, so they are either added to the outbox below
+		// or add themselves when they see the saved result status
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Take(&Match{}, matchResult.MatchID).Error; err != nil {
+			return fmt.Errorf("failed to lock match: %w", err)
+		}
+
+		fixture := FootballApiFixture{ID: matchResult.FixtureID}
+		if err := tx.Model(&fixture).Updates(FootballApiFixture{Data: *dataAsJson, Provider: matchResult.Provider}).Error; err != nil {
+			return fmt.Errorf("failed to update fixture: %w", err)
+		}
+
+		if err := tx.Model(&Match{ID: matchResult.MatchID}).Updates(Match{ResultStatus: matchResult.ResultStatus}).Error; err != nil {
+			return fmt.Errorf("failed to update result status: %w", err)
+		}
+
+		if err := addToOutbox(tx, matchResult.MatchID, nil); err != nil {
+			return fmt.Errorf("failed to add subscribers to notification outbox: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -17,7 +17,8 @@ type Alias struct {
 type Team struct {
 	ID uint `gorm:"column:id;primaryKey"`
 
-	Aliases []Alias
+	Aliases         []Alias
+	FootballApiTeam *FootballApiTeam `gorm:"foreignKey:TeamID"`
 }
 
 type FootballApiTeam struct {
@@ -31,6 +32,9 @@ type Match struct {
 	AwayTeamID   uint         `gorm:"column:away_team_id"`
 	StartsAt     time.Time    `gorm:"column:starts_at"`
 	ResultStatus ResultStatus `gorm:"column:result_status;default:not_scheduled"`
+	// TraceID and SpanID are of the request which created the match. Polling spans are linked to it.
+	TraceID *string `gorm:"column:trace_id"`
+	SpanID  *string `gorm:"column:span_id"`
 
 	FootballApiFixtures []FootballApiFixture
 	HomeTeam            *Team `gorm:"foreignKey:HomeTeamID"`
@@ -38,9 +42,10 @@ type Match struct {
 }
 
 type FootballApiFixture struct {
-	ID      uint         `gorm:"column:id;primaryKey"`
-	MatchID uint         `gorm:"column:match_id"`
-	Data    pgtype.JSONB `gorm:"column:data"`
+	ID       uint         `gorm:"column:id;primaryKey"`
+	MatchID  uint         `gorm:"column:match_id"`
+	Data     pgtype.JSONB `gorm:"column:data"`
+	Provider string       `gorm:"column:provider;default:api-football"`
 
 	Match *Match `gorm:"foreignKey:MatchID"`
 }
@@ -57,6 +62,56 @@ type Subscription struct {
 	Match *Match `gorm:"foreignKey:MatchID"`
 }
 
+// OutboxNotification is a pending notification of the subscriber. It is created in the transaction which saves
+// the match result and is removed in the transaction which saves the subscription status.
+type OutboxNotification struct {
+	ID             uint      `gorm:"column:id;primaryKey"`
+	SubscriptionID uint      `gorm:"column:subscription_id"`
+	MatchID        uint      `gorm:"column:match_id"`
+	CreatedAt      time.Time `gorm:"column:created_at;default:now()"`
+	// LockedUntil is the end of the lease of the notifier which claimed the notification
+	LockedUntil *time.Time `gorm:"column:locked_until"`
+
+	Subscription *Subscription `gorm:"foreignKey:SubscriptionID"`
+}
+
+func (OutboxNotification) TableName() string {
+	return "notification_outbox"
+}
+
+// MatchResult is the result of the match received from the provider.
+type MatchResult struct {
+	MatchID      uint
+	FixtureID    uint
+	Data         Data
+	Provider     string
+	ResultStatus ResultStatus
+}
+
+type TeamImport struct {
+	SourceTeamID      uint
+	FootballAPITeamID *uint
+	Aliases           []string
+}
+
+type ImportResult struct {
+	CreatedTeams            int
+	CreatedAliases          int
+	CreatedFootballAPITeams int
+	Conflicts               []ImportConflict
+}
+
+type ImportConflict struct {
+	SourceTeamID uint
+	Alias        string
+	Reason       string
+}
+
+type MigrationVersion struct {
+	Version uint `gorm:"column:version"`
+	Dirty   bool `gorm:"column:dirty"`
+}
+
 type ResultStatus string
 
 const (
@@ -65,8 +120,11 @@ const (
 	SchedulingError ResultStatus = "scheduling_error"
 	Error           ResultStatus = "error"
 	Successful      ResultStatus = "successful"
+	NeedsReview     ResultStatus = "needs_review"
 )
 
+var ResultStatuses = []ResultStatus{NotScheduled, Scheduled, SchedulingError, Error, Successful, NeedsReview}
+
 type SubscriptionStatus string
 
 const (
@@ -77,6 +135,7 @@ const (
 
 type Data struct {
 	Fixture Fixture       `json:"fixture"`
+	League  FixtureLeague `json:"league"`
 	Teams   TeamsExternal `json:"teams"`
 	Goals   Goals         `json:"goals"`
 }
@@ -87,6 +146,12 @@ type Fixture struct {
 	Date   string `json:"date"`
 }
 
+type FixtureLeague struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Round string `json:"round"`
+}
+
 type TeamsExternal struct {
 	Home TeamExternal `json:"home"`
 	Away TeamExternal `json:"away"`
@@ -103,6 +168,7 @@ type Goals struct {
 }
 
 type Status struct {
-	Short string `json:"short"`
-	Long  string `json:"long"`
+	Short   string `json:"short"`
+	Long    string `json:"long"`
+	Elapsed *uint  `json:"elapsed"`
 }
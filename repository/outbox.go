@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/andrewshostak/result-service/errs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Claim locks at most limit notifications which are not claimed or whose lease is expired until lockedUntil.
+// The oldest notifications are claimed first, notifications claimed by other instances at the same moment are skipped.
+// Lease times are written in UTC, so instances in different time zones compare them the same way.
+func (r *OutboxRepository) Claim(ctx context.Context, now time.Time, lockedUntil time.Time, limit int) ([]OutboxNotification, error) {
+	return r.claim(ctx, r.claimable(now, limit), lockedUntil)
+}
+
+// ClaimByMatch claims notifications of one match.
+func (r *OutboxRepository) ClaimByMatch(ctx context.Context, matchID uint, now time.Time, lockedUntil time.Time, limit int) ([]OutboxNotification, error) {
+	return r.claim(ctx, r.claimable(now, limit).Where("match_id = ?", matchID), lockedUntil)
+}
+
+// CompleteInTrx saves the status of the notified subscription and removes its notification from the outbox.
+// errs.ErrNotificationLeaseExpired is returned when the notification is claimed again after the lease of the caller,
+// nothing is saved then, the notifier holding the new lease completes it.
+func (r *OutboxRepository) CompleteInTrx(ctx context.Context, notification OutboxNotification, subscription Subscription) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Subscription{ID: notification.SubscriptionID}).Updates(subscription).Error; err != nil {
+			return fmt.Errorf("failed to update subscription: %w", err)
+		}
+
+		var lockedUntil *time.Time
+		if notification.LockedUntil != nil {
+			utc := notification.LockedUntil.UTC()
+			lockedUntil = &utc
+		}
+
+		result := tx.Where("id = ? and locked_until = ?", notification.ID, lockedUntil).Delete(&OutboxNotification{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete outbox notification: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("outbox notification %d: %w", notification.ID, errs.ErrNotificationLeaseExpired)
+		}
+
+		return nil
+	})
+}
+
+func (r *OutboxRepository) claimable(now time.Time, limit int) *gorm.DB {
+	return r.db.Model(&OutboxNotification{}).
+		Select("id").
+		Where("locked_until is null or locked_until <= ?", now.UTC()).
+		Order("id").
+		Limit(limit).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+}
+
+func (r *OutboxRepository) claim(ctx context.Context, claimable *gorm.DB, lockedUntil time.Time) ([]OutboxNotification, error) {
+	var claimed []OutboxNotification
+	result := r.db.WithContext(ctx).
+		Model(&claimed).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+		Where("id in (?)", claimable).
+		Update("locked_until", lockedUntil.UTC())
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if len(claimed) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint, 0, len(claimed))
+	for i := range claimed {
+		ids = append(ids, claimed[i].ID)
+	}
+
+	var notifications []OutboxNotification
+	result = r.db.WithContext(ctx).
+		Where("id in ?", ids).
+		Preload("Subscription.Match.FootballApiFixtures").
+		Order("id").
+		Find(&notifications)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return notifications, nil
+}
+
+// addToOutbox creates notifications of pending subscriptions of the match when its result is successful.
+// The subscription id is optional, it limits notifications to one subscription.
+func addToOutbox(tx *gorm.DB, matchID uint, subscriptionID *uint) error {
+	pending := tx.Model(&Subscription{}).
+		Select("subscriptions.id, subscriptions.match_id").
+		Joins("join matches on matches.id = subscriptions.match_id").
+		Where("subscriptions.match_id = ?", matchID).
+		Where("subscriptions.status = ?", PendingSub).
+		Where("matches.result_status = ?", Successful)
+
+	if subscriptionID != nil {
+		pending = pending.Where("subscriptions.id = ?", *subscriptionID)
+	}
+
+	return tx.Exec("insert into notification_outbox (subscription_id, match_id) ? on conflict (subscription_id) do nothing", pending).Error
+}
@@ -0,0 +1,154 @@
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/andrewshostak/result-service/errs"
+	"github.com/andrewshostak/result-service/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxRepository_Claim(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, time.January, 20, 17, 0, 0, 0, time.UTC)
+	lockedUntil := now.Add(10 * time.Minute)
+
+	claim := regexp.QuoteMeta(`UPDATE "notification_outbox" SET "locked_until"=$1 WHERE id in (SELECT "id" FROM "notification_outbox" WHERE locked_until is null or locked_until <= $2 ORDER BY id LIMIT 20 FOR UPDATE SKIP LOCKED) RETURNING "id"`)
+
+	t.Run("it should lock a limited batch of claimable notifications and load their subscriptions", func(t *testing.T) {
+		db, mock := newMockDB(t)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(claim).WithArgs(lockedUntil, now).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(101).AddRow(102))
+		mock.ExpectCommit()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "notification_outbox" WHERE id in ($1,$2) ORDER BY id`)).WithArgs(101, 102).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "subscription_id", "match_id", "locked_until"}).
+				AddRow(101, 1, 7, lockedUntil).
+				AddRow(102, 2, 7, lockedUntil))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "subscriptions" WHERE "subscriptions"."id" IN ($1,$2)`)).WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "match_id"}).AddRow(1, 7).AddRow(2, 7))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches" WHERE "matches"."id" = $1`)).WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "football_api_fixtures" WHERE "football_api_fixtures"."match_id" = $1`)).WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "match_id"}).AddRow(1035330, 7))
+
+		notifications, err := repository.NewOutboxRepository(db).Claim(ctx, now, lockedUntil, 20)
+
+		assert.NoError(t, err)
+		require.Len(t, notifications, 2)
+		assert.Equal(t, uint(101), notifications[0].ID)
+		assert.Equal(t, &lockedUntil, notifications[0].LockedUntil)
+		assert.Equal(t, uint(1035330), notifications[1].Subscription.Match.FootballApiFixtures[0].ID)
+	})
+
+	t.Run("it should return nothing when there are no claimable notifications", func(t *testing.T) {
+		db, mock := newMockDB(t)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(claim).WithArgs(lockedUntil, now).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectCommit()
+
+		notifications, err := repository.NewOutboxRepository(db).Claim(ctx, now, lockedUntil, 20)
+
+		assert.NoError(t, err)
+		assert.Empty(t, notifications)
+	})
+
+	t.Run("it should write and compare the lease in utc when the local time zone is not utc", func(t *testing.T) {
+		setLocalTimeZone(t, "America/New_York")
+		db, mock := newMockDB(t)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(claim).WithArgs(lockedUntil, now).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectCommit()
+
+		notifications, err := repository.NewOutboxRepository(db).Claim(ctx, now.Local(), lockedUntil.Local(), 20)
+
+		assert.NoError(t, err)
+		assert.Empty(t, notifications)
+	})
+}
+
+func TestOutboxRepository_ClaimByMatch(t *testing.T) {
+	now := time.Date(2024, time.January, 20, 17, 0, 0, 0, time.UTC)
+	lockedUntil := now.Add(10 * time.Minute)
+
+	db, mock := newMockDB(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE "notification_outbox" SET "locked_until"=$1 WHERE id in (SELECT "id" FROM "notification_outbox" WHERE (locked_until is null or locked_until <= $2) AND match_id = $3 ORDER BY id LIMIT 5 FOR UPDATE SKIP LOCKED) RETURNING "id"`)).
+		WithArgs(lockedUntil, now, 7).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+
+	notifications, err := repository.NewOutboxRepository(db).ClaimByMatch(context.Background(), 7, now, lockedUntil, 5)
+
+	assert.NoError(t, err)
+	assert.Empty(t, notifications)
+}
+
+func TestOutboxRepository_CompleteInTrx(t *testing.T) {
+	ctx := context.Background()
+	lockedUntil := time.Date(2024, time.January, 20, 17, 10, 0, 0, time.UTC)
+	notification := repository.OutboxNotification{ID: 101, SubscriptionID: 1, MatchID: 7, LockedUntil: &lockedUntil}
+
+	updateSubscription := regexp.QuoteMeta(`UPDATE "subscriptions" SET "status"=$1 WHERE "id" = $2`)
+	deleteNotification := regexp.QuoteMeta(`DELETE FROM "notification_outbox" WHERE id = $1 and locked_until = $2`)
+
+	t.Run("it should save the subscription status and delete the notification claimed by the caller", func(t *testing.T) {
+		db, mock := newMockDB(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(updateSubscription).WithArgs(repository.SuccessfulSub, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(deleteNotification).WithArgs(101, lockedUntil).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repository.NewOutboxRepository(db).CompleteInTrx(ctx, notification, repository.Subscription{Status: repository.SuccessfulSub})
+		assert.NoError(t, err)
+	})
+
+	t.Run("it should save nothing when the notification is claimed again after the lease", func(t *testing.T) {
+		db, mock := newMockDB(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(updateSubscription).WithArgs(repository.SuccessfulSub, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(deleteNotification).WithArgs(101, lockedUntil).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repository.NewOutboxRepository(db).CompleteInTrx(ctx, notification, repository.Subscription{Status: repository.SuccessfulSub})
+		assert.ErrorIs(t, err, errs.ErrNotificationLeaseExpired)
+	})
+
+	t.Run("it should compare the lease in utc when the local time zone is not utc", func(t *testing.T) {
+		setLocalTimeZone(t, "America/New_York")
+		db, mock := newMockDB(t)
+
+		localLockedUntil := lockedUntil.Local()
+		claimed := notification
+		claimed.LockedUntil = &localLockedUntil
+
+		mock.ExpectBegin()
+		mock.ExpectExec(updateSubscription).WithArgs(repository.SuccessfulSub, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(deleteNotification).WithArgs(101, lockedUntil).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repository.NewOutboxRepository(db).CompleteInTrx(ctx, claimed, repository.Subscription{Status: repository.SuccessfulSub})
+		assert.NoError(t, err)
+	})
+}
+
+// setLocalTimeZone replaces the local time zone of the process until the end of the test.
+func setLocalTimeZone(t *testing.T, name string) {
+	t.Helper()
+
+	location, err := time.LoadLocation(name)
+	require.NoError(t, err)
+
+	local := time.Local
+	time.Local = location
+	t.Cleanup(func() { time.Local = local })
+}
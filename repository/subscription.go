@@ -17,17 +17,25 @@ func NewSubscriptionRepository(db *gorm.DB) *SubscriptionRepository {
 	return &SubscriptionRepository{db: db}
 }
 
+// Create creates the subscription. When the result of the match is saved while the subscription is being created,
+// the subscription is added to the notification outbox in the same transaction.
 func (r *SubscriptionRepository) Create(ctx context.Context, subscription Subscription) (*Subscription, error) {
-	result := r.db.WithContext(ctx).Create(&subscription)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrForeignKeyViolated) {
-			return nil, fmt.Errorf("match id does not exist: %w", errs.WrongMatchIDError{Message: result.Error.Error()})
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&subscription).Error; err != nil {
+			return err
+		}
+
+		return addToOutbox(tx, subscription.MatchID, &subscription.ID)
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return nil, fmt.Errorf("match id does not exist: %w", errs.WrongMatchIDError{Message: err.Error()})
 		}
-		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
-			return nil, fmt.Errorf("subscription already exists: %w", errs.SubscriptionAlreadyExistsError{Message: result.Error.Error()})
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, fmt.Errorf("subscription already exists: %w", errs.SubscriptionAlreadyExistsError{Message: err.Error()})
 		}
 
-		return nil, result.Error
+		return nil, err
 	}
 
 	return &subscription, nil
@@ -78,22 +86,6 @@ func (r *SubscriptionRepository) List(ctx context.Context, matchID uint) ([]Subs
 	return subscriptions, nil
 }
 
-func (r *SubscriptionRepository) ListUnNotified(ctx context.Context) ([]Subscription, error) {
-	var subscriptions []Subscription
-	result := r.db.WithContext(ctx).
-		Where("status = ?", PendingSub).
-		Joins("Match").
-		Where("result_status = ?", Successful).
-		Preload("Match.FootballApiFixtures").
-		Find(&subscriptions)
-
-	if result.Error != nil {
-		return nil, result.Error
-	}
-
-	return subscriptions, nil
-}
-
 func (r *SubscriptionRepository) Update(ctx context.Context, id uint, subscription Subscription) error {
 	sub := Subscription{ID: id}
 	result := r.db.WithContext(ctx).Model(&sub).Updates(subscription)
@@ -3,36 +3,187 @@ package scheduler
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/andrewshostak/result-service/errs"
 	"github.com/procyon-projects/chrono"
 )
 
+// Task schedules tasks by keys on timers of the clock, so a fake clock moves scheduled tasks in tests.
 type Task struct {
-	scheduler   chrono.TaskScheduler
-	activeTasks map[string]chrono.ScheduledTask
+	clock       clock.Clock
+	mutex       sync.Mutex
+	activeTasks map[string]*scheduledTask
+
+	// ctx is passed to tasks, it is cancelled when running tasks don't finish before the stop deadline
+	ctx     context.Context
+	cancel  context.CancelFunc
+	running sync.WaitGroup
+	stopped bool
 }
 
-func NewTaskScheduler(scheduler chrono.TaskScheduler) *Task {
-	return &Task{scheduler: scheduler, activeTasks: map[string]chrono.ScheduledTask{}}
+func NewTaskScheduler(clock clock.Clock) *Task {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Task{clock: clock, activeTasks: map[string]*scheduledTask{}, ctx: ctx, cancel: cancel}
 }
 
 func (s *Task) Schedule(key string, task func(ctx context.Context), period time.Duration, startTime time.Time) error {
-	scheduledTask, err := s.scheduler.ScheduleAtFixedRate(task, period, chrono.WithTime(startTime))
+	if period <= 0 {
+		return fmt.Errorf("failed to schedule a task: period must be positive, got %s", period)
+	}
+
+	scheduled := &scheduledTask{}
+	if err := s.add(key, scheduled); err != nil {
+		return err
+	}
+	s.run(scheduled, task, startTime, func(previous time.Time) time.Time {
+		return previous.Add(period)
+	})
+
+	return nil
+}
+
+// ScheduleOnce schedules a task which runs only once at startTime.
+func (s *Task) ScheduleOnce(key string, task func(ctx context.Context), startTime time.Time) error {
+	scheduled := &scheduledTask{}
+	if err := s.add(key, scheduled); err != nil {
+		return err
+	}
+	s.run(scheduled, task, startTime, nil)
+
+	return nil
+}
 
+// ScheduleWithCron schedules a task by cron expression with seconds field, for example "0 0 4 * * MON".
+// The expression is evaluated in UTC.
+func (s *Task) ScheduleWithCron(key string, task func(ctx context.Context), expression string) error {
+	cron, err := chrono.ParseCronExpression(expression)
 	if err != nil {
-		return fmt.Errorf("failed to schedule a task: %w", err)
+		return fmt.Errorf("failed to schedule a cron task: %w", err)
 	}
 
-	s.activeTasks[key] = scheduledTask
+	next := func(time.Time) time.Time {
+		return cron.NextTime(s.clock.Now().In(time.UTC))
+	}
+
+	scheduled := &scheduledTask{}
+	if err := s.add(key, scheduled); err != nil {
+		return err
+	}
+	s.run(scheduled, task, next(time.Time{}), next)
 
 	return nil
 }
 
 func (s *Task) Cancel(key string) {
-	scheduledTask, ok := s.activeTasks[key]
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	scheduled, ok := s.activeTasks[key]
 	if ok {
-		scheduledTask.Cancel()
+		scheduled.cancel()
 		delete(s.activeTasks, key)
 	}
 }
+
+// Stop cancels timers of all tasks, so no task starts anymore, and waits for running tasks.
+// When ctx is done before they finish, the context of the tasks is cancelled and ctx error is returned.
+// Tasks can't be scheduled after Stop, scheduling returns errs.ErrShuttingDown.
+func (s *Task) Stop(ctx context.Context) error {
+	s.mutex.Lock()
+	s.stopped = true
+	for key, scheduled := range s.activeTasks {
+		scheduled.cancel()
+		delete(s.activeTasks, key)
+	}
+	s.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.cancel()
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		return fmt.Errorf("running tasks are not finished: %w", ctx.Err())
+	}
+}
+
+// add saves the scheduled task by key. A task previously scheduled with the same key is cancelled.
+func (s *Task) add(key string, scheduled *scheduledTask) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stopped {
+		return fmt.Errorf("failed to schedule a task: %w", errs.ErrShuttingDown)
+	}
+
+	if previous, ok := s.activeTasks[key]; ok {
+		previous.cancel()
+	}
+
+	s.activeTasks[key] = scheduled
+
+	return nil
+}
+
+// begin registers a running task. It returns false when the scheduler is stopped and the task must not run.
+func (s *Task) begin() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stopped {
+		return false
+	}
+
+	s.running.Add(1)
+
+	return true
+}
+
+// run starts the timer of the task at startTime. When next is set, the task is scheduled again at the time returned by next.
+func (s *Task) run(scheduled *scheduledTask, task func(ctx context.Context), startTime time.Time, next func(previous time.Time) time.Time) {
+	scheduled.mutex.Lock()
+	defer scheduled.mutex.Unlock()
+
+	if scheduled.cancelled {
+		return
+	}
+
+	scheduled.timer = s.clock.AfterFunc(startTime.Sub(s.clock.Now()), func() {
+		if !s.begin() {
+			return
+		}
+		defer s.running.Done()
+
+		task(s.ctx)
+
+		if next != nil {
+			s.run(scheduled, task, next(startTime), next)
+		}
+	})
+}
+
+type scheduledTask struct {
+	mutex     sync.Mutex
+	timer     clock.Timer
+	cancelled bool
+}
+
+func (t *scheduledTask) cancel() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.cancelled = true
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+}
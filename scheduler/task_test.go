@@ -0,0 +1,80 @@
+package scheduler_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/andrewshostak/result-service/errs"
+	"github.com/andrewshostak/result-service/scheduler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTask_Stop(t *testing.T) {
+	t.Run("it waits for the running task and doesn't run scheduled tasks after stop", func(t *testing.T) {
+		now := time.Date(2024, time.January, 20, 15, 0, 0, 0, time.UTC)
+		fakeClock := clock.NewFake(now)
+		s := scheduler.NewTaskScheduler(fakeClock)
+
+		started, release := make(chan struct{}), make(chan struct{})
+		var finished atomic.Bool
+		assert.NoError(t, s.ScheduleOnce("running", func(ctx context.Context) {
+			close(started)
+			<-release
+			finished.Store(true)
+		}, now.Add(time.Minute)))
+
+		var pendingRuns atomic.Int32
+		assert.NoError(t, s.ScheduleOnce("pending", func(ctx context.Context) {
+			pendingRuns.Add(1)
+		}, now.Add(2*time.Minute)))
+
+		go fakeClock.Advance(time.Minute)
+		<-started
+
+		stopped := make(chan error)
+		go func() {
+			stopped <- s.Stop(context.Background())
+		}()
+
+		select {
+		case <-stopped:
+			t.Fatal("stop returned before the running task is finished")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		close(release)
+		assert.NoError(t, <-stopped)
+		assert.True(t, finished.Load())
+
+		fakeClock.Advance(time.Minute)
+		assert.Equal(t, int32(0), pendingRuns.Load())
+
+		err := s.ScheduleOnce("new", func(ctx context.Context) {}, now.Add(3*time.Minute))
+		assert.ErrorIs(t, err, errs.ErrShuttingDown)
+	})
+
+	t.Run("it cancels the context of the running task when the deadline is exceeded", func(t *testing.T) {
+		now := time.Date(2024, time.January, 20, 15, 0, 0, 0, time.UTC)
+		fakeClock := clock.NewFake(now)
+		s := scheduler.NewTaskScheduler(fakeClock)
+
+		started, cancelled := make(chan struct{}), make(chan struct{})
+		assert.NoError(t, s.ScheduleOnce("running", func(ctx context.Context) {
+			close(started)
+			<-ctx.Done()
+			close(cancelled)
+		}, now.Add(time.Minute)))
+
+		go fakeClock.Advance(time.Minute)
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		assert.ErrorIs(t, s.Stop(ctx), context.DeadlineExceeded)
+		<-cancelled
+	})
+}
@@ -2,7 +2,11 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+
+	"github.com/andrewshostak/result-service/errs"
+	"github.com/andrewshostak/result-service/repository"
 )
 
 type AliasService struct {
@@ -30,3 +34,53 @@ func (s *AliasService) Search(ctx context.Context, alias string) ([]string, erro
 
 	return aliases, nil
 }
+
+func (s *AliasService) Export(ctx context.Context) ([]TeamAliases, error) {
+	teams, err := s.aliasRepository.ListTeams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	result := make([]TeamAliases, 0, len(teams))
+	for i := range teams {
+		result = append(result, fromRepositoryTeamAliases(teams[i]))
+	}
+
+	return result, nil
+}
+
+// Import saves teams with their aliases in one transaction. Existing teams are matched by football api team id
+// or by aliases, only missing data is created. If there are conflicts nothing is saved, and the result containing
+// conflicts is returned together with AliasImportConflictError.
+func (s *AliasService) Import(ctx context.Context, teams []TeamAliases) (*AliasImportResult, error) {
+	toImport := make([]repository.TeamImport, 0, len(teams))
+	for i := range teams {
+		if len(teams[i].Aliases) == 0 {
+			return nil, fmt.Errorf("team %d doesn't have aliases", teams[i].TeamID)
+		}
+
+		toImport = append(toImport, toRepositoryTeamImport(teams[i]))
+	}
+
+	result, err := s.aliasRepository.ImportInTrx(ctx, toImport)
+	if errors.As(err, &errs.AliasImportConflictError{}) {
+		mapped := fromRepositoryImportResult(*result)
+		s.logger.Info().Int("number_of_conflicts", len(mapped.Conflicts)).Msg("aliases import rolled back due to conflicts")
+
+		return &mapped, fmt.Errorf("failed to import aliases: %w", err)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to import aliases: %w", err)
+	}
+
+	mapped := fromRepositoryImportResult(*result)
+
+	s.logger.Info().
+		Int("created_teams", mapped.CreatedTeams).
+		Int("created_aliases", mapped.CreatedAliases).
+		Int("created_football_api_teams", mapped.CreatedFootballAPITeams).
+		Msg("aliases imported")
+
+	return &mapped, nil
+}
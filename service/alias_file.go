@@ -0,0 +1,176 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const (
+	AliasFileFormatCSV  = "csv"
+	AliasFileFormatJSON = "json"
+)
+
+var aliasFileCSVHeader = []string{"team_id", "football_api_team_id", "alias"}
+
+type teamAliasesFileItem struct {
+	TeamID            uint     `json:"team_id"`
+	FootballAPITeamID *uint    `json:"football_api_team_id"`
+	Aliases           []string `json:"aliases"`
+}
+
+// WriteTeamAliases encodes teams in csv (one row per alias) or json (one item per team) format.
+func WriteTeamAliases(w io.Writer, format string, teams []TeamAliases) error {
+	switch format {
+	case AliasFileFormatJSON:
+		items := make([]teamAliasesFileItem, 0, len(teams))
+		for i := range teams {
+			items = append(items, teamAliasesFileItem{
+				TeamID:            teams[i].TeamID,
+				FootballAPITeamID: teams[i].FootballAPITeamID,
+				Aliases:           teams[i].Aliases,
+			})
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(items); err != nil {
+			return fmt.Errorf("failed to encode json: %w", err)
+		}
+
+		return nil
+	case AliasFileFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(aliasFileCSVHeader); err != nil {
+			return fmt.Errorf("failed to write csv header: %w", err)
+		}
+
+		for i := range teams {
+			footballAPITeamID := ""
+			if teams[i].FootballAPITeamID != nil {
+				footballAPITeamID = strconv.Itoa(int(*teams[i].FootballAPITeamID))
+			}
+
+			for _, alias := range teams[i].Aliases {
+				if err := writer.Write([]string{strconv.Itoa(int(teams[i].TeamID)), footballAPITeamID, alias}); err != nil {
+					return fmt.Errorf("failed to write csv row: %w", err)
+				}
+			}
+		}
+
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return fmt.Errorf("failed to flush csv: %w", err)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unknown aliases file format %s", format)
+	}
+}
+
+// ReadTeamAliases decodes teams written by WriteTeamAliases. Csv rows are grouped into teams by team_id column.
+func ReadTeamAliases(r io.Reader, format string) ([]TeamAliases, error) {
+	switch format {
+	case AliasFileFormatJSON:
+		var items []teamAliasesFileItem
+		if err := json.NewDecoder(r).Decode(&items); err != nil {
+			return nil, fmt.Errorf("failed to decode json: %w", err)
+		}
+
+		teams := make([]TeamAliases, 0, len(items))
+		for i := range items {
+			if len(items[i].Aliases) == 0 {
+				return nil, fmt.Errorf("item %d: aliases cannot be empty", i)
+			}
+
+			teams = append(teams, TeamAliases{
+				TeamID:            items[i].TeamID,
+				FootballAPITeamID: items[i].FootballAPITeamID,
+				Aliases:           items[i].Aliases,
+			})
+		}
+
+		return teams, nil
+	case AliasFileFormatCSV:
+		return readTeamAliasesCSV(r)
+	default:
+		return nil, fmt.Errorf("unknown aliases file format %s", format)
+	}
+}
+
+func readTeamAliasesCSV(r io.Reader) ([]TeamAliases, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(aliasFileCSVHeader)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	for i := range aliasFileCSVHeader {
+		if header[i] != aliasFileCSVHeader[i] {
+			return nil, fmt.Errorf("unexpected csv header %v, expected %v", header, aliasFileCSVHeader)
+		}
+	}
+
+	teams := make([]TeamAliases, 0)
+	indexes := map[uint]int{}
+
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv row: %w", err)
+		}
+
+		teamID, err := strconv.ParseUint(row[0], 10, 64)
+		if err != nil || teamID == 0 {
+			return nil, fmt.Errorf("line %d: team_id must be a positive number", line)
+		}
+
+		var footballAPITeamID *uint
+		if row[1] != "" {
+			parsed, err := strconv.ParseUint(row[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: football_api_team_id must be a number: %w", line, err)
+			}
+
+			id := uint(parsed)
+			footballAPITeamID = &id
+		}
+
+		if row[2] == "" {
+			return nil, fmt.Errorf("line %d: alias cannot be empty", line)
+		}
+
+		index, ok := indexes[uint(teamID)]
+		if !ok {
+			teams = append(teams, TeamAliases{TeamID: uint(teamID), FootballAPITeamID: footballAPITeamID})
+			index = len(teams) - 1
+			indexes[uint(teamID)] = index
+		}
+
+		if !equalIDs(teams[index].FootballAPITeamID, footballAPITeamID) {
+			return nil, fmt.Errorf("line %d: team %d has different football_api_team_id values", line, teamID)
+		}
+
+		teams[index].Aliases = append(teams[index].Aliases, row[2])
+	}
+
+	return teams, nil
+}
+
+func equalIDs(a *uint, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return *a == *b
+}
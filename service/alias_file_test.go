@@ -0,0 +1,51 @@
+package service_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/andrewshostak/result-service/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamAliasesFile(t *testing.T) {
+	footballAPITeamID := uint(33)
+	teams := []service.TeamAliases{
+		{TeamID: 1, FootballAPITeamID: &footballAPITeamID, Aliases: []string{"Manchester United", "Man Utd"}},
+		{TeamID: 2, Aliases: []string{"Dnipro-1"}},
+	}
+
+	for _, format := range []string{service.AliasFileFormatCSV, service.AliasFileFormatJSON} {
+		t.Run("it should read teams written in "+format+" format", func(t *testing.T) {
+			var buffer bytes.Buffer
+			assert.NoError(t, service.WriteTeamAliases(&buffer, format, teams))
+
+			result, err := service.ReadTeamAliases(&buffer, format)
+			assert.NoError(t, err)
+			assert.Equal(t, teams, result)
+		})
+	}
+
+	t.Run("it should write one csv row per alias", func(t *testing.T) {
+		var buffer bytes.Buffer
+		assert.NoError(t, service.WriteTeamAliases(&buffer, service.AliasFileFormatCSV, teams))
+		assert.Equal(t, "team_id,football_api_team_id,alias\n1,33,Manchester United\n1,33,Man Utd\n2,,Dnipro-1\n", buffer.String())
+	})
+
+	t.Run("it should return error when csv rows of a team have different football api team ids", func(t *testing.T) {
+		input := "team_id,football_api_team_id,alias\n1,33,Manchester United\n1,34,Man Utd\n"
+
+		result, err := service.ReadTeamAliases(strings.NewReader(input), service.AliasFileFormatCSV)
+		assert.EqualError(t, err, "line 3: team 1 has different football_api_team_id values")
+		assert.Nil(t, result)
+	})
+
+	t.Run("it should return error when csv header is unexpected", func(t *testing.T) {
+		input := "id,alias,football_api_team_id\n1,Man Utd,33\n"
+
+		result, err := service.ReadTeamAliases(strings.NewReader(input), service.AliasFileFormatCSV)
+		assert.ErrorContains(t, err, "unexpected csv header")
+		assert.Nil(t, result)
+	})
+}
@@ -2,37 +2,108 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/andrewshostak/result-service/client"
+	"github.com/andrewshostak/result-service/errs"
+	"golang.org/x/sync/errgroup"
 )
 
 type BackfillAliasesService struct {
 	aliasRepository   AliasRepository
 	footballAPIClient FootballAPIClient
 	logger            Logger
+	clock             Clock
+	numberOfWorkers   uint
+
+	mutex   sync.RWMutex
+	lastRun *BackfillRun
 }
 
 func NewBackfillAliasesService(
 	aliasRepository AliasRepository,
 	footballAPIClient FootballAPIClient,
 	logger Logger,
+	clock Clock,
+	numberOfWorkers uint,
 ) *BackfillAliasesService {
 	return &BackfillAliasesService{
 		aliasRepository:   aliasRepository,
 		footballAPIClient: footballAPIClient,
 		logger:            logger,
+		clock:             clock,
+		numberOfWorkers:   numberOfWorkers,
 	}
 }
 
-func (s *BackfillAliasesService) Backfill(ctx context.Context, season uint) error {
-	s.logger.Info().Msg("starting aliases backfill")
+// BackfillCurrentSeason runs backfill of the current season and remembers its result.
+// Only one run at a time is allowed.
+func (s *BackfillAliasesService) BackfillCurrentSeason(ctx context.Context, trigger string) error {
+	season := uint(getSeason(s.clock.Now().UTC()))
+
+	s.mutex.Lock()
+	if s.lastRun != nil && s.lastRun.FinishedAt == nil {
+		s.mutex.Unlock()
+		return errors.New("aliases backfill is already running")
+	}
+
+	run := &BackfillRun{Season: season, Trigger: trigger, StartedAt: s.clock.Now()}
+	s.lastRun = run
+	s.mutex.Unlock()
+
+	report, err := s.Backfill(ctx, season, false)
+
+	s.mutex.Lock()
+	finishedAt := s.clock.Now()
+	s.lastRun = &BackfillRun{
+		Season:     run.Season,
+		Trigger:    run.Trigger,
+		StartedAt:  run.StartedAt,
+		FinishedAt: &finishedAt,
+		Report:     report,
+		Err:        err,
+	}
+	s.mutex.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("failed to backfill aliases of season %d: %w", season, err)
+	}
+
+	return nil
+}
+
+// LastRun returns the last run started by BackfillCurrentSeason, or nil when there were no runs.
+func (s *BackfillAliasesService) LastRun() *BackfillRun {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.lastRun == nil {
+		return nil
+	}
+
+	run := *s.lastRun
+	return &run
+}
+
+// NextSeasonStart returns the moment when the next season begins.
+func (s *BackfillAliasesService) NextSeasonStart() time.Time {
+	return getNextSeasonStart(s.clock.Now().UTC())
+}
+
+// Backfill creates aliases of the teams playing in the included leagues of the season.
+// When dryRun is true nothing is written, and the report describes the aliases that would be created.
+// Failed leagues don't stop the others: the report is returned together with an error summarizing all failures.
+func (s *BackfillAliasesService) Backfill(ctx context.Context, season uint, dryRun bool) (*BackfillReport, error) {
+	s.logger.Info().Bool("dry_run", dryRun).Msg("starting aliases backfill")
+	ctx = client.WithPriority(ctx, client.PriorityLow)
 	s.logger.Info().Uint("season", season).Msg("searching leagues")
 
 	result, err := s.footballAPIClient.SearchLeagues(ctx, season)
 	if err != nil {
-		return fmt.Errorf("failed to search leagues: %w", err)
+		return nil, fmt.Errorf("failed to search leagues: %w", err)
 	}
 
 	s.logger.Info().Int("length", len(result.Response)).Msg("leagues found")
@@ -46,27 +117,28 @@ func (s *BackfillAliasesService) Backfill(ctx context.Context, season uint) erro
 
 	s.logger.Info().Int("length", len(leagues)).Msg("leagues filtering is done")
 
-	leaguesTeams, err := s.getLeaguesTeams(ctx, leagues, season)
-	if err != nil {
-		return fmt.Errorf("failed to get teams: %w", err)
-	}
+	leaguesTeams := s.getLeaguesTeams(ctx, leagues, season)
+	reports := s.saveTeams(ctx, excludeDuplicatedTeams(leaguesTeams), dryRun)
 
-	s.saveTeams(ctx, leaguesTeams)
+	report := &BackfillReport{Season: season, DryRun: dryRun, Leagues: reports}
 
-	return nil
+	return report, s.summarizeErrors(reports)
 }
 
-func (s *BackfillAliasesService) getLeaguesTeams(ctx context.Context, leagues []LeagueData, season uint) (map[LeagueData][]TeamExternal, error) {
-	const numberOfWorkers = 3
-	jobs := make(chan struct{}, numberOfWorkers)
-	wg := sync.WaitGroup{}
-	var mutex = &sync.RWMutex{}
+func (s *BackfillAliasesService) getLeaguesTeams(ctx context.Context, leagues []LeagueData, season uint) []leagueTeams {
+	teams := make([]leagueTeams, len(leagues))
 
-	teams := map[LeagueData][]TeamExternal{}
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(s.workers())
 
 	for i := range leagues {
-		wg.Add(1)
-		jobs <- struct{}{}
+		i := i
+		teams[i].league = leagues[i]
+
+		if gCtx.Err() != nil {
+			teams[i].err = gCtx.Err()
+			continue
+		}
 
 		s.logger.Info().
 			Int("number", i).
@@ -74,16 +146,23 @@ func (s *BackfillAliasesService) getLeaguesTeams(ctx context.Context, leagues []
 			Str("country_name", leagues[i].Country.Name).
 			Msg("iterating leagues")
 
-		go func(ctx context.Context, league LeagueData) {
-			result, err := s.footballAPIClient.SearchTeams(ctx, client.TeamsSearch{Season: season, League: league.League.ID})
-			<-jobs
+		g.Go(func() error {
+			league := leagues[i]
+			if gCtx.Err() != nil {
+				teams[i].err = gCtx.Err()
+				return gCtx.Err()
+			}
 
+			result, err := s.footballAPIClient.SearchTeams(gCtx, client.TeamsSearch{Season: season, League: league.League.ID})
 			if err != nil {
 				s.logger.Error().Err(err).
 					Str("league_name", league.League.Name).
 					Str("country_name", league.Country.Name).
 					Msg("failed to get teams")
-				return
+				teams[i].err = fmt.Errorf("failed to get teams: %w", err)
+
+				// only cancellation stops other leagues
+				return ctx.Err()
 			}
 
 			s.logger.Info().
@@ -92,19 +171,17 @@ func (s *BackfillAliasesService) getLeaguesTeams(ctx context.Context, leagues []
 				Int("number_of_teams", len(result.Response)).
 				Msg("successfully get teams")
 
-			mutex.Lock()
-			teams[league] = fromClientFootballAPITeams(result.Response)
-			mutex.Unlock()
+			teams[i].teams = fromClientFootballAPITeams(result.Response)
 
-			defer wg.Done()
-		}(ctx, leagues[i])
+			return nil
+		})
 	}
 
-	wg.Wait()
+	_ = g.Wait()
 
 	s.logger.Info().Int("number_of_leagues", len(teams)).Msg("all teams received")
 
-	return teams, nil
+	return teams
 }
 
 func (s *BackfillAliasesService) filterOutLeagues(allLeagues []LeagueData, includedLeagues []league) []LeagueData {
@@ -158,54 +235,240 @@ func (s *BackfillAliasesService) getIncludedLeagues() []league {
 	}
 }
 
-func (s *BackfillAliasesService) saveTeams(ctx context.Context, leaguesTeams map[LeagueData][]TeamExternal) {
-	const numberOfWorkers = 3
-	jobs := make(chan struct{}, numberOfWorkers)
-	wg := sync.WaitGroup{}
+func (s *BackfillAliasesService) saveTeams(ctx context.Context, leaguesTeams []leagueTeams, dryRun bool) []LeagueBackfillReport {
+	reports := make([]LeagueBackfillReport, len(leaguesTeams))
 
-	for league, teams := range leaguesTeams {
-		wg.Add(1)
-		jobs <- struct{}{}
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(s.workers())
+
+	for i := range leaguesTeams {
+		i := i
+		reports[i] = LeagueBackfillReport{League: leaguesTeams[i].league, Err: leaguesTeams[i].err}
+
+		if reports[i].Err != nil {
+			continue
+		}
 
-		go func(league LeagueData, teams []TeamExternal) {
-			numberOfSaved, numberOfExisted := 0, 0
-			for i := range teams {
-				_, err := s.aliasRepository.Find(ctx, teams[i].Name)
-				if err == nil {
-					s.logger.Info().
-						Str("alias", teams[i].Name).
-						Uint("football_api_team_id", teams[i].ID).
-						Msg("alias already exists")
-					numberOfExisted++
-					continue
+		if gCtx.Err() != nil {
+			reports[i].Err = gCtx.Err()
+			continue
+		}
+
+		g.Go(func() error {
+			report := &reports[i]
+			teams := leaguesTeams[i].teams
+
+			var teamErrors []error
+			for j := range teams {
+				if gCtx.Err() != nil {
+					teamErrors = append(teamErrors, gCtx.Err())
+					break
 				}
 
-				errTrx := s.aliasRepository.SaveInTrx(ctx, teams[i].Name, teams[i].ID)
-				if errTrx != nil {
-					s.logger.Error().
-						Str("alias", teams[i].Name).
-						Uint("football_api_team_id", teams[i].ID).
-						Err(errTrx).
-						Msg("failed to save alias")
-					continue
+				err := s.saveLeagueTeam(gCtx, report, teams[j], dryRun)
+				if err != nil {
+					report.Failed = append(report.Failed, teams[j])
+					teamErrors = append(teamErrors, fmt.Errorf("team %s (%d): %w", teams[j].Name, teams[j].ID, err))
 				}
-				numberOfSaved++
 			}
 
-			<-jobs
+			if len(teamErrors) > 0 {
+				report.Err = fmt.Errorf("failed to save %d of %d team(s): %w", len(report.Failed), len(teams), errors.Join(teamErrors...))
+			}
 
 			s.logger.Info().
-				Str("league_name", league.League.Name).
-				Str("country_name", league.Country.Name).
-				Int("number_of_saved", numberOfSaved).
-				Int("number_of_existed", numberOfExisted).
+				Str("league_name", report.League.League.Name).
+				Str("country_name", report.League.Country.Name).
+				Int("number_of_saved", len(report.Created)).
+				Int("number_of_renamed", len(report.Renamed)).
+				Int("number_of_existed", len(report.Existing)).
+				Int("number_of_conflicts", len(report.Conflicts)).
+				Int("number_of_failed", len(report.Failed)).
+				Bool("dry_run", dryRun).
 				Msg("league teams saving finished")
 
-			defer wg.Done()
-		}(league, teams)
+			// only cancellation stops other leagues
+			return ctx.Err()
+		})
+	}
+
+	_ = g.Wait()
+
+	return reports
+}
+
+func (s *BackfillAliasesService) saveLeagueTeam(ctx context.Context, report *LeagueBackfillReport, team TeamExternal, dryRun bool) error {
+	check, err := s.checkTeam(ctx, team)
+	if err != nil {
+		s.logger.Error().
+			Str("alias", team.Name).
+			Uint("football_api_team_id", team.ID).
+			Err(err).
+			Msg("failed to check alias")
+		return err
+	}
+
+	switch check.action {
+	case backfillActionSkip:
+		report.Existing = append(report.Existing, team)
+	case backfillActionConflict:
+		s.logger.Info().
+			Str("alias", team.Name).
+			Uint("football_api_team_id", team.ID).
+			Str("reason", check.conflict.Reason).
+			Msg("alias conflicts with existing data")
+		report.Conflicts = append(report.Conflicts, *check.conflict)
+	case backfillActionRename:
+		if err := s.saveRename(ctx, *check.rename, dryRun); err != nil {
+			return err
+		}
+		report.Renamed = append(report.Renamed, *check.rename)
+	case backfillActionCreate:
+		if err := s.saveTeam(ctx, team, dryRun); err != nil {
+			return err
+		}
+		report.Created = append(report.Created, team)
+	}
+
+	return nil
+}
+
+func (s *BackfillAliasesService) summarizeErrors(reports []LeagueBackfillReport) error {
+	var leagueErrors []error
+	for i := range reports {
+		if reports[i].Err != nil {
+			leagueErrors = append(leagueErrors, fmt.Errorf("league %s (%s): %w", reports[i].League.League.Name, reports[i].League.Country.Name, reports[i].Err))
+		}
+	}
+
+	if len(leagueErrors) == 0 {
+		return nil
+	}
+
+	s.logger.Error().Int("number_of_failed_leagues", len(leagueErrors)).Msg("aliases backfill finished with errors")
+
+	return fmt.Errorf("%d of %d league(s) failed: %w", len(leagueErrors), len(reports), errors.Join(leagueErrors...))
+}
+
+func (s *BackfillAliasesService) workers() int {
+	if s.numberOfWorkers == 0 {
+		return 1
 	}
 
-	wg.Wait()
+	return int(s.numberOfWorkers)
+}
+
+func (s *BackfillAliasesService) saveTeam(ctx context.Context, team TeamExternal, dryRun bool) error {
+	if dryRun {
+		return nil
+	}
+
+	err := s.aliasRepository.SaveInTrx(ctx, team.Name, team.ID)
+	if err != nil {
+		s.logger.Error().
+			Str("alias", team.Name).
+			Uint("football_api_team_id", team.ID).
+			Err(err).
+			Msg("failed to save alias")
+		return err
+	}
+
+	return nil
+}
+
+func (s *BackfillAliasesService) saveRename(ctx context.Context, rename AliasRename, dryRun bool) error {
+	if dryRun {
+		return nil
+	}
+
+	_, err := s.aliasRepository.Create(ctx, rename.ExistingTeamID, rename.Team.Name)
+	if err != nil {
+		s.logger.Error().
+			Str("alias", rename.Team.Name).
+			Uint("football_api_team_id", rename.Team.ID).
+			Uint("team_id", rename.ExistingTeamID).
+			Err(err).
+			Msg("failed to add alias to existing team")
+		return err
+	}
+
+	s.logger.Info().
+		Str("alias", rename.Team.Name).
+		Str("existing_alias", rename.ExistingAlias).
+		Uint("team_id", rename.ExistingTeamID).
+		Msg("renamed team alias added")
+
+	return nil
+}
+
+// checkTeam decides what backfill should do with the team. Football api team id is checked first,
+// so a renamed team gets its new name as an extra alias instead of becoming a separate team.
+// A conflict is returned when the team cannot be saved without breaking existing aliases.
+func (s *BackfillAliasesService) checkTeam(ctx context.Context, team TeamExternal) (*teamCheck, error) {
+	foundByID, err := s.aliasRepository.FindByFootballAPITeamID(ctx, team.ID)
+	if err != nil && !errors.As(err, &errs.AliasNotFoundError{}) {
+		return nil, fmt.Errorf("failed to find alias by football api team id: %w", err)
+	}
+
+	found, errFind := s.aliasRepository.Find(ctx, team.Name)
+	if errFind != nil && !errors.As(errFind, &errs.AliasNotFoundError{}) {
+		return nil, fmt.Errorf("failed to find alias: %w", errFind)
+	}
+
+	if foundByID != nil {
+		linked := fromRepositoryAlias(*foundByID)
+
+		if found == nil {
+			return &teamCheck{
+				action: backfillActionRename,
+				rename: &AliasRename{Team: team, ExistingAlias: linked.Alias, ExistingTeamID: linked.TeamID},
+			}, nil
+		}
+
+		if found.TeamID == linked.TeamID {
+			return &teamCheck{action: backfillActionSkip}, nil
+		}
+
+		return &teamCheck{action: backfillActionConflict, conflict: newAliasConflict(
+			team,
+			fromRepositoryAlias(*found),
+			"football api team is linked to a team, but the alias belongs to another team",
+		)}, nil
+	}
+
+	if found == nil {
+		return &teamCheck{action: backfillActionCreate}, nil
+	}
+
+	alias := fromRepositoryAlias(*found)
+	if alias.FootballApiTeam == nil {
+		return &teamCheck{action: backfillActionConflict, conflict: newAliasConflict(
+			team,
+			alias,
+			"alias exists, but its team is not linked to football api team",
+		)}, nil
+	}
+
+	return &teamCheck{action: backfillActionConflict, conflict: newAliasConflict(
+		team,
+		alias,
+		"alias exists, but it is linked to another football api team",
+	)}, nil
+}
+
+func newAliasConflict(team TeamExternal, existing Alias, reason string) *AliasConflict {
+	conflict := &AliasConflict{
+		Team:           team,
+		ExistingAlias:  existing.Alias,
+		ExistingTeamID: existing.TeamID,
+		Reason:         reason,
+	}
+
+	if existing.FootballApiTeam != nil {
+		conflict.ExistingFootballAPITeamID = existing.FootballApiTeam.ID
+	}
+
+	return conflict
 }
 
 func isIncludedLeague(league LeagueData, includedLeagues []league) bool {
@@ -222,3 +485,47 @@ type league struct {
 	name    string
 	country string
 }
+
+// excludeDuplicatedTeams keeps a team only in the first league it plays in.
+// Otherwise, concurrent league workers would try to create the same team twice.
+func excludeDuplicatedTeams(leaguesTeams []leagueTeams) []leagueTeams {
+	seen := map[uint]struct{}{}
+	result := make([]leagueTeams, 0, len(leaguesTeams))
+
+	for i := range leaguesTeams {
+		teams := make([]TeamExternal, 0, len(leaguesTeams[i].teams))
+		for _, team := range leaguesTeams[i].teams {
+			if _, ok := seen[team.ID]; ok {
+				continue
+			}
+
+			seen[team.ID] = struct{}{}
+			teams = append(teams, team)
+		}
+
+		result = append(result, leagueTeams{league: leaguesTeams[i].league, teams: teams, err: leaguesTeams[i].err})
+	}
+
+	return result
+}
+
+type leagueTeams struct {
+	league LeagueData
+	teams  []TeamExternal
+	err    error
+}
+
+type teamCheck struct {
+	action   backfillAction
+	conflict *AliasConflict
+	rename   *AliasRename
+}
+
+type backfillAction int
+
+const (
+	backfillActionCreate backfillAction = iota
+	backfillActionRename
+	backfillActionSkip
+	backfillActionConflict
+)
@@ -0,0 +1,230 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andrewshostak/result-service/client"
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/andrewshostak/result-service/errs"
+	"github.com/andrewshostak/result-service/repository"
+	"github.com/andrewshostak/result-service/service"
+	"github.com/andrewshostak/result-service/service/mocks"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBackfillAliasesService_Backfill(t *testing.T) {
+	ctx := context.Background()
+	season := uint(2023)
+
+	league := client.LeagueResult{
+		League:  client.League{ID: 39, Name: "Premier League"},
+		Country: client.Country{Name: "England"},
+	}
+	newTeam := client.Team{ID: 1, Name: "Luton"}
+	existingTeam := client.Team{ID: 2, Name: "Arsenal"}
+	renamedTeam := client.Team{ID: 3, Name: "Sheffield Utd"}
+	conflictingTeam := client.Team{ID: 4, Name: "Everton"}
+
+	setup := func(t *testing.T) (*service.BackfillAliasesService, *mocks.AliasRepository) {
+		aliasRepository := mocks.NewAliasRepository(t)
+		footballAPIClient := mocks.NewFootballAPIClient(t)
+		logger := mocks.NewLogger(t)
+
+		logger.On("Info").Return(nil)
+		footballAPIClient.On("SearchLeagues", client.WithPriority(ctx, client.PriorityLow), season).
+			Return(&client.LeaguesResponse{Response: []client.LeagueResult{league}}, nil).Once()
+		footballAPIClient.On("SearchTeams", mock.Anything, client.TeamsSearch{Season: season, League: league.League.ID}).
+			Return(&client.TeamsResponse{Response: []client.TeamsResult{{Team: newTeam}, {Team: existingTeam}, {Team: renamedTeam}, {Team: conflictingTeam}}}, nil).Once()
+
+		notFound := errs.AliasNotFoundError{Message: gofakeit.Sentence(2)}
+		aliasRepository.On("FindByFootballAPITeamID", mock.Anything, newTeam.ID).Return(nil, notFound).Once()
+		aliasRepository.On("Find", mock.Anything, newTeam.Name).Return(nil, notFound).Once()
+
+		existingAlias := fakeLinkedRepositoryAlias(10, existingTeam.ID, existingTeam.Name)
+		aliasRepository.On("FindByFootballAPITeamID", mock.Anything, existingTeam.ID).Return(&existingAlias, nil).Once()
+		aliasRepository.On("Find", mock.Anything, existingTeam.Name).Return(&existingAlias, nil).Once()
+
+		renamedAlias := fakeLinkedRepositoryAlias(11, renamedTeam.ID, "Sheffield United")
+		aliasRepository.On("FindByFootballAPITeamID", mock.Anything, renamedTeam.ID).Return(&renamedAlias, nil).Once()
+		aliasRepository.On("Find", mock.Anything, renamedTeam.Name).Return(nil, notFound).Once()
+
+		linkedAlias := fakeLinkedRepositoryAlias(12, conflictingTeam.ID, "Everton FC")
+		otherAlias := fakeLinkedRepositoryAlias(13, 40, conflictingTeam.Name)
+		aliasRepository.On("FindByFootballAPITeamID", mock.Anything, conflictingTeam.ID).Return(&linkedAlias, nil).Once()
+		aliasRepository.On("Find", mock.Anything, conflictingTeam.Name).Return(&otherAlias, nil).Once()
+
+		return service.NewBackfillAliasesService(aliasRepository, footballAPIClient, logger, clock.New(), 3), aliasRepository
+	}
+
+	expectedLeague := service.LeagueData{
+		League:  service.League{ID: league.League.ID, Name: league.League.Name},
+		Country: service.Country{Name: league.Country.Name},
+	}
+	expectedRename := service.AliasRename{
+		Team:           service.TeamExternal{ID: renamedTeam.ID, Name: renamedTeam.Name},
+		ExistingAlias:  "Sheffield United",
+		ExistingTeamID: 11,
+	}
+	expectedConflict := service.AliasConflict{
+		Team:                      service.TeamExternal{ID: conflictingTeam.ID, Name: conflictingTeam.Name},
+		ExistingAlias:             conflictingTeam.Name,
+		ExistingTeamID:            13,
+		ExistingFootballAPITeamID: 40,
+		Reason:                    "football api team is linked to a team, but the alias belongs to another team",
+	}
+	expectedReport := func(dryRun bool) *service.BackfillReport {
+		return &service.BackfillReport{
+			Season: season,
+			DryRun: dryRun,
+			Leagues: []service.LeagueBackfillReport{{
+				League:    expectedLeague,
+				Created:   []service.TeamExternal{{ID: newTeam.ID, Name: newTeam.Name}},
+				Renamed:   []service.AliasRename{expectedRename},
+				Existing:  []service.TeamExternal{{ID: existingTeam.ID, Name: existingTeam.Name}},
+				Conflicts: []service.AliasConflict{expectedConflict},
+			}},
+		}
+	}
+
+	t.Run("it should report aliases to create, renames and conflicts without saving in dry run mode", func(t *testing.T) {
+		s, _ := setup(t)
+
+		report, err := s.Backfill(ctx, season, true)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedReport(true), report)
+	})
+
+	t.Run("it should save new teams and add aliases of renamed teams when dry run is disabled", func(t *testing.T) {
+		s, aliasRepository := setup(t)
+		aliasRepository.On("SaveInTrx", mock.Anything, newTeam.Name, newTeam.ID).Return(nil).Once()
+		aliasRepository.On("Create", mock.Anything, uint(11), renamedTeam.Name).Return(&repository.Alias{TeamID: 11, Alias: renamedTeam.Name}, nil).Once()
+
+		report, err := s.Backfill(ctx, season, false)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedReport(false), report)
+	})
+
+	t.Run("it should return report and aggregated error when teams of a league are not received", func(t *testing.T) {
+		aliasRepository := mocks.NewAliasRepository(t)
+		footballAPIClient := mocks.NewFootballAPIClient(t)
+		logger := mocks.NewLogger(t)
+
+		logger.On("Info").Return(nil)
+		logger.On("Error").Return(nil)
+
+		otherLeague := client.LeagueResult{
+			League:  client.League{ID: 140, Name: "La Liga"},
+			Country: client.Country{Name: "Spain"},
+		}
+		footballAPIClient.On("SearchLeagues", client.WithPriority(ctx, client.PriorityLow), season).
+			Return(&client.LeaguesResponse{Response: []client.LeagueResult{league, otherLeague}}, nil).Once()
+		footballAPIClient.On("SearchTeams", mock.Anything, client.TeamsSearch{Season: season, League: league.League.ID}).
+			Return(&client.TeamsResponse{}, nil).Once()
+		errClient := errors.New(gofakeit.Sentence(2))
+		footballAPIClient.On("SearchTeams", mock.Anything, client.TeamsSearch{Season: season, League: otherLeague.League.ID}).
+			Return(nil, errClient).Once()
+
+		s := service.NewBackfillAliasesService(aliasRepository, footballAPIClient, logger, clock.New(), 1)
+
+		report, err := s.Backfill(ctx, season, false)
+		assert.ErrorIs(t, err, errClient)
+		assert.ErrorContains(t, err, "1 of 2 league(s) failed")
+		assert.Len(t, report.Leagues, 2)
+		assert.NoError(t, report.Leagues[0].Err)
+		assert.ErrorIs(t, report.Leagues[1].Err, errClient)
+	})
+
+	t.Run("it should stop processing leagues when context is cancelled", func(t *testing.T) {
+		aliasRepository := mocks.NewAliasRepository(t)
+		footballAPIClient := mocks.NewFootballAPIClient(t)
+		logger := mocks.NewLogger(t)
+
+		logger.On("Info").Return(nil)
+		logger.On("Error").Return(nil)
+
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		footballAPIClient.On("SearchLeagues", client.WithPriority(cancelledCtx, client.PriorityLow), season).
+			Return(&client.LeaguesResponse{Response: []client.LeagueResult{league}}, nil).Once().
+			Run(func(_ mock.Arguments) { cancel() })
+
+		s := service.NewBackfillAliasesService(aliasRepository, footballAPIClient, logger, clock.New(), 3)
+
+		report, err := s.Backfill(cancelledCtx, season, false)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Len(t, report.Leagues, 1)
+	})
+}
+
+func TestBackfillAliasesService_BackfillCurrentSeason(t *testing.T) {
+	t.Run("it should refuse to start a run while the previous one is running", func(t *testing.T) {
+		footballAPIClient := mocks.NewFootballAPIClient(t)
+		logger := mocks.NewLogger(t)
+
+		logger.On("Info").Return(nil)
+
+		now := time.Date(2024, time.August, 1, 4, 0, 0, 0, time.UTC)
+		started, release := make(chan struct{}), make(chan struct{})
+		footballAPIClient.On("SearchLeagues", mock.Anything, uint(2024)).
+			Return(&client.LeaguesResponse{}, nil).Once().
+			Run(func(_ mock.Arguments) {
+				close(started)
+				<-release
+			})
+
+		s := service.NewBackfillAliasesService(mocks.NewAliasRepository(t), footballAPIClient, logger, clock.NewFake(now), 1)
+
+		done := make(chan error)
+		go func() {
+			done <- s.BackfillCurrentSeason(context.Background(), "schedule")
+		}()
+		<-started
+
+		assert.EqualError(t, s.BackfillCurrentSeason(context.Background(), "manual"), "aliases backfill is already running")
+		running := s.LastRun()
+		assert.Equal(t, "schedule", running.Trigger)
+		assert.Nil(t, running.FinishedAt)
+
+		close(release)
+		assert.NoError(t, <-done)
+
+		finished := s.LastRun()
+		assert.Equal(t, uint(2024), finished.Season)
+		assert.NotNil(t, finished.FinishedAt)
+	})
+}
+
+func TestBackfillAliasesService_NextSeasonStart(t *testing.T) {
+	bound := time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected time.Time
+	}{
+		{name: "before the bound", now: bound.Add(-time.Nanosecond), expected: bound.Add(time.Nanosecond)},
+		{name: "at the bound the previous season is still current", now: bound, expected: bound.Add(time.Nanosecond)},
+		{name: "after the bound", now: bound.Add(time.Nanosecond), expected: bound.AddDate(1, 0, 0).Add(time.Nanosecond)},
+		{name: "at the end of the year", now: time.Date(2024, time.December, 31, 23, 0, 0, 0, time.UTC), expected: bound.AddDate(1, 0, 0).Add(time.Nanosecond)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := service.NewBackfillAliasesService(mocks.NewAliasRepository(t), mocks.NewFootballAPIClient(t), mocks.NewLogger(t), clock.NewFake(tt.now), 1)
+			assert.Equal(t, tt.expected, s.NextSeasonStart())
+		})
+	}
+}
+
+func fakeLinkedRepositoryAlias(teamID uint, footballAPITeamID uint, alias string) repository.Alias {
+	return repository.Alias{
+		ID:              uint(gofakeit.Uint8()),
+		TeamID:          teamID,
+		Alias:           alias,
+		FootballApiTeam: &repository.FootballApiTeam{ID: footballAPITeamID, TeamID: teamID},
+	}
+}
@@ -5,12 +5,17 @@ import (
 	"time"
 
 	"github.com/andrewshostak/result-service/client"
+	"github.com/andrewshostak/result-service/clock"
 	"github.com/andrewshostak/result-service/repository"
 	"github.com/rs/zerolog"
 )
 
 type AliasRepository interface {
+	Create(ctx context.Context, teamID uint, alias string) (*repository.Alias, error)
 	Find(ctx context.Context, alias string) (*repository.Alias, error)
+	FindByFootballAPITeamID(ctx context.Context, footballAPITeamID uint) (*repository.Alias, error)
+	ImportInTrx(ctx context.Context, teams []repository.TeamImport) (*repository.ImportResult, error)
+	ListTeams(ctx context.Context) ([]repository.Team, error)
 	SaveInTrx(ctx context.Context, alias string, footballAPITeamID uint) error
 	Search(ctx context.Context, alias string) ([]repository.Alias, error)
 }
@@ -21,11 +26,11 @@ type MatchRepository interface {
 	List(ctx context.Context, resultStatus repository.ResultStatus) ([]repository.Match, error)
 	One(ctx context.Context, search repository.Match) (*repository.Match, error)
 	Update(ctx context.Context, id uint, resultStatus repository.ResultStatus) (*repository.Match, error)
+	SaveResultInTrx(ctx context.Context, matchResult repository.MatchResult) error
 }
 
 type FootballAPIFixtureRepository interface {
 	Create(ctx context.Context, fixture repository.FootballApiFixture, data repository.Data) (*repository.FootballApiFixture, error)
-	Update(ctx context.Context, id uint, data repository.Data) (*repository.FootballApiFixture, error)
 }
 
 type FootballAPIClient interface {
@@ -34,6 +39,16 @@ type FootballAPIClient interface {
 	SearchTeams(ctx context.Context, search client.TeamsSearch) (*client.TeamsResponse, error)
 }
 
+type ResultFeedClient interface {
+	SearchMatches(ctx context.Context, date string) (*client.ResultFeedResponse, error)
+}
+
+// ResultProvider is a source of match results. It returns the fixture data with actual status and goals.
+type ResultProvider interface {
+	ID() string
+	Result(ctx context.Context, query ResultQuery) (*Data, error)
+}
+
 type NotifierClient interface {
 	Notify(ctx context.Context, notification client.Notification) error
 }
@@ -43,12 +58,17 @@ type SubscriptionRepository interface {
 	Delete(ctx context.Context, id uint) error
 	One(ctx context.Context, matchID uint, key string, baseURL string) (*repository.Subscription, error)
 	List(ctx context.Context, matchID uint) ([]repository.Subscription, error)
-	ListUnNotified(ctx context.Context) ([]repository.Subscription, error)
-	Update(ctx context.Context, id uint, subscription repository.Subscription) error
+}
+
+// OutboxRepository keeps notifications of subscribers of matches with saved results until they are delivered.
+type OutboxRepository interface {
+	Claim(ctx context.Context, now time.Time, lockedUntil time.Time, limit int) ([]repository.OutboxNotification, error)
+	ClaimByMatch(ctx context.Context, matchID uint, now time.Time, lockedUntil time.Time, limit int) ([]repository.OutboxNotification, error)
+	CompleteInTrx(ctx context.Context, notification repository.OutboxNotification, subscription repository.Subscription) error
 }
 
 type TaskScheduler interface {
-	Schedule(key string, task func(ctx context.Context), period time.Duration, startTime time.Time) error
+	ScheduleOnce(key string, task func(ctx context.Context), startTime time.Time) error
 	Cancel(key string)
 }
 
@@ -56,7 +76,51 @@ type SeasonHelper interface {
 	CurrentSeason() int
 }
 
+type FixtureBatcher interface {
+	Fixture(ctx context.Context, id uint) (*client.Result, error)
+}
+
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (*repository.MigrationVersion, error)
+}
+
+// NotifierMonitor reports the liveness of the notifier loop.
+type NotifierMonitor interface {
+	LastTick() time.Time
+	Interval() time.Duration
+	// Failures returns the number of consecutive failed runs and the error of the last one
+	Failures() (uint, error)
+}
+
+type FootballAPIStatusClient interface {
+	Status(ctx context.Context) error
+}
+
+type FootballAPIQuotaTracker interface {
+	Quota() *client.Quota
+}
+
+// Clock is the source of the current time and timers. It is replaced by a fake clock in tests.
+type Clock interface {
+	Now() time.Time
+	AfterFunc(d time.Duration, f func()) clock.Timer
+}
+
+// ResultPublisher triggers notification of subscribers of the match when its result is saved.
+type ResultPublisher interface {
+	PublishMatchResult(ctx context.Context, matchID uint) error
+}
+
+type Metrics interface {
+	PollingAttempt(outcome string)
+	Notification(outcome string)
+	ObserveNotifierRun(duration time.Duration)
+}
+
 type Logger interface {
+	Debug() *zerolog.Event
 	Error() *zerolog.Event
 	Info() *zerolog.Event
+	Warn() *zerolog.Event
 }
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/andrewshostak/result-service/client"
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/andrewshostak/result-service/errs"
+)
+
+// maxFixturesBatchSize is the max number of fixture ids football-api accepts in ids param.
+const maxFixturesBatchSize = 20
+
+// FixturePollingCoordinator groups fixture requests received within the window into a single football-api call with ids param
+// and fans the results out to the callers. Polling tasks of matches with the same kick-off run at the same time,
+// so they share one request instead of making a request each.
+type FixturePollingCoordinator struct {
+	footballAPIClient FootballAPIClient
+	logger            Logger
+	clock             Clock
+	window            time.Duration
+
+	mutex   sync.Mutex
+	pending map[uint][]chan fixtureBatchResult
+	ctx     context.Context
+	timer   clock.Timer
+	stopped bool
+
+	// inFlight counts batch requests being sent, they are cancelled with lifetime when Stop deadline is exceeded
+	inFlight       sync.WaitGroup
+	lifetime       context.Context
+	cancelLifetime context.CancelFunc
+}
+
+type fixtureBatchResult struct {
+	fixture *client.Result
+	err     error
+}
+
+func NewFixturePollingCoordinator(footballAPIClient FootballAPIClient, logger Logger, clock Clock, window time.Duration) *FixturePollingCoordinator {
+	lifetime, cancelLifetime := context.WithCancel(context.Background())
+
+	return &FixturePollingCoordinator{
+		footballAPIClient: footballAPIClient,
+		logger:            logger,
+		clock:             clock,
+		window:            window,
+		pending:           map[uint][]chan fixtureBatchResult{},
+		lifetime:          lifetime,
+		cancelLifetime:    cancelLifetime,
+	}
+}
+
+// Fixture returns the fixture by id. The request is sent when the window elapses or the batch is full.
+func (b *FixturePollingCoordinator) Fixture(ctx context.Context, id uint) (*client.Result, error) {
+	ch := make(chan fixtureBatchResult, 1)
+
+	b.mutex.Lock()
+	if b.stopped {
+		b.mutex.Unlock()
+		return nil, fmt.Errorf("fixture polling coordinator: %w", errs.ErrShuttingDown)
+	}
+
+	if len(b.pending) == 0 {
+		// the batch request is not cancelled with the context of the first caller, but keeps its values
+		b.ctx = context.WithoutCancel(ctx)
+	}
+
+	b.pending[id] = append(b.pending[id], ch)
+
+	if len(b.pending) >= maxFixturesBatchSize {
+		b.flushLocked()
+	} else if b.timer == nil {
+		b.timer = b.clock.AfterFunc(b.window, b.flush)
+	}
+	b.mutex.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-ch:
+		return result.fixture, result.err
+	}
+}
+
+// Stop sends the pending batch without waiting for the window and waits for batch requests being sent.
+// When ctx is done before they finish, the requests are cancelled and ctx error is returned.
+func (b *FixturePollingCoordinator) Stop(ctx context.Context) error {
+	b.mutex.Lock()
+	b.stopped = true
+	b.flushLocked()
+	b.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		b.cancelLifetime()
+		return nil
+	case <-ctx.Done():
+		b.cancelLifetime()
+		return fmt.Errorf("batch requests are not finished: %w", ctx.Err())
+	}
+}
+
+func (b *FixturePollingCoordinator) flush() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.flushLocked()
+}
+
+func (b *FixturePollingCoordinator) flushLocked() {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+
+	if len(b.pending) == 0 {
+		return
+	}
+
+	b.inFlight.Add(1)
+	go b.send(b.ctx, b.pending)
+
+	b.pending = map[uint][]chan fixtureBatchResult{}
+	b.ctx = nil
+}
+
+func (b *FixturePollingCoordinator) send(ctx context.Context, batch map[uint][]chan fixtureBatchResult) {
+	defer b.inFlight.Done()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(b.lifetime, cancel)
+	defer stop()
+
+	ids := make([]uint, 0, len(batch))
+	for id := range batch {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	b.logger.Info().Interface("ids", ids).Msg("searching batch of fixtures")
+
+	response, err := b.footballAPIClient.SearchFixtures(ctx, client.FixtureSearch{Timezone: time.UTC.String(), IDs: ids})
+	if err != nil {
+		err = fmt.Errorf("failed to search batch of %d fixtures: %w", len(ids), err)
+		for _, id := range ids {
+			reply(batch[id], fixtureBatchResult{err: err})
+		}
+
+		return
+	}
+
+	fixtures := make(map[uint]client.Result, len(response.Response))
+	for _, fixture := range response.Response {
+		fixtures[fixture.Fixture.ID] = fixture
+	}
+
+	for _, id := range ids {
+		fixture, ok := fixtures[id]
+		if !ok {
+			reply(batch[id], fixtureBatchResult{err: fmt.Errorf("fixture %d: %w", id, errs.ErrResultNotFound)})
+			continue
+		}
+
+		reply(batch[id], fixtureBatchResult{fixture: &fixture})
+	}
+}
+
+func reply(chs []chan fixtureBatchResult, result fixtureBatchResult) {
+	for _, ch := range chs {
+		ch <- result
+	}
+}
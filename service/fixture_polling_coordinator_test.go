@@ -0,0 +1,100 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andrewshostak/result-service/client"
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/andrewshostak/result-service/errs"
+	"github.com/andrewshostak/result-service/service"
+	"github.com/andrewshostak/result-service/service/mocks"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// waitingContext closes waiting when Done is called the first time. Fixture calls Done only after the caller
+// is added to the batch, so the test knows when to move the clock.
+type waitingContext struct {
+	context.Context
+	once    sync.Once
+	waiting chan struct{}
+}
+
+func (c *waitingContext) Done() <-chan struct{} {
+	c.once.Do(func() { close(c.waiting) })
+	return c.Context.Done()
+}
+
+func TestFixturePollingCoordinator_Fixture(t *testing.T) {
+	window := 5 * time.Second
+
+	fetchConcurrently := func(c *service.FixturePollingCoordinator, fakeClock *clock.Fake, ids ...uint) ([]*client.Result, []error) {
+		results := make([]*client.Result, len(ids))
+		errList := make([]error, len(ids))
+
+		var wg sync.WaitGroup
+		for i, id := range ids {
+			ctx := &waitingContext{Context: context.Background(), waiting: make(chan struct{})}
+
+			wg.Add(1)
+			go func(i int, id uint) {
+				defer wg.Done()
+				results[i], errList[i] = c.Fixture(ctx, id)
+			}(i, id)
+
+			<-ctx.waiting
+		}
+
+		fakeClock.Advance(window - time.Nanosecond)
+		require.Equal(t, 1, fakeClock.Timers(), "batch is sent before the window elapses")
+
+		fakeClock.Advance(time.Nanosecond)
+		wg.Wait()
+
+		return results, errList
+	}
+
+	t.Run("it should request fixtures of the window in one call and return each caller its fixture", func(t *testing.T) {
+		footballAPIClient := mocks.NewFootballAPIClient(t)
+		logger := mocks.NewLogger(t)
+		logger.On("Info").Return(nil)
+
+		first := client.Result{Fixture: client.Fixture{ID: 1}, Goals: client.Goals{Home: 2, Away: 1}}
+		second := client.Result{Fixture: client.Fixture{ID: 2}, Goals: client.Goals{Home: 0, Away: 0}}
+		footballAPIClient.On("SearchFixtures", mock.Anything, client.FixtureSearch{Timezone: time.UTC.String(), IDs: []uint{1, 2, 3}}).
+			Return(&client.FixturesResponse{Response: []client.Result{second, first}}, nil).Once()
+
+		fakeClock := clock.NewFake(time.Now())
+		c := service.NewFixturePollingCoordinator(footballAPIClient, logger, fakeClock, window)
+
+		results, errList := fetchConcurrently(c, fakeClock, 1, 2, 3, 1)
+		assert.Equal(t, []*client.Result{&first, &second, nil, &first}, results)
+		assert.NoError(t, errList[0])
+		assert.NoError(t, errList[1])
+		assert.ErrorIs(t, errList[2], errs.ErrResultNotFound)
+		assert.NoError(t, errList[3])
+	})
+
+	t.Run("it should return the error of the batch request to every caller", func(t *testing.T) {
+		footballAPIClient := mocks.NewFootballAPIClient(t)
+		logger := mocks.NewLogger(t)
+		logger.On("Info").Return(nil)
+
+		errClient := errors.New(gofakeit.Sentence(2))
+		footballAPIClient.On("SearchFixtures", mock.Anything, client.FixtureSearch{Timezone: time.UTC.String(), IDs: []uint{4, 5}}).
+			Return(nil, errClient).Once()
+
+		fakeClock := clock.NewFake(time.Now())
+		c := service.NewFixturePollingCoordinator(footballAPIClient, logger, fakeClock, window)
+
+		_, errList := fetchConcurrently(c, fakeClock, 4, 5)
+		assert.ErrorIs(t, errList[0], errClient)
+		assert.ErrorIs(t, errList[1], errClient)
+	})
+}
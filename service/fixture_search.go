@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/andrewshostak/result-service/client"
+	"github.com/andrewshostak/result-service/errs"
+)
+
+// maxKickOffDifference is the max difference between requested and football-api kick-off time of the same fixture.
+const maxKickOffDifference = 3 * time.Hour
+
+// findFixture searches a fixture of home and away football-api teams on the date of startsAt.
+// The season of the date is tried first, then adjacent seasons, since tournaments of national teams and cups
+// may belong to a season which doesn't follow the club season boundary.
+func (s *MatchService) findFixture(ctx context.Context, homeTeamID uint, awayTeamID uint, startsAt time.Time) (*client.Result, error) {
+	date := startsAt.UTC().Format(dateFormat)
+	season := uint(getSeason(startsAt.UTC()))
+
+	for _, candidateSeason := range []uint{season, season - 1, season + 1} {
+		response, err := s.footballAPIClient.SearchFixtures(ctx, client.FixtureSearch{
+			Season:   &candidateSeason,
+			Timezone: time.UTC.String(),
+			Date:     &date,
+			TeamID:   &homeTeamID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to search fixtures of season %d in external api: %w", candidateSeason, err)
+		}
+
+		candidates := matchFixtures(response.Response, homeTeamID, awayTeamID, startsAt)
+		if len(candidates) == 1 {
+			return &candidates[0], nil
+		}
+
+		if len(candidates) > 1 {
+			return nil, errs.AmbiguousFixtureError{Message: fmt.Sprintf(
+				"%d fixtures starting at %s with home team id %d and away team id %d are found in external api: %s",
+				len(candidates), date, homeTeamID, awayTeamID, describeFixtures(candidates),
+			)}
+		}
+
+		s.logger.Info().Uint("season", candidateSeason).Str("date", date).Uint("home_team_id", homeTeamID).
+			Uint("away_team_id", awayTeamID).Msg("fixture is not found in the season")
+	}
+
+	return nil, errs.UnexpectedNumberOfItemsError{Message: fmt.Sprintf(
+		"fixture starting at %s with home team id %d and away team id %d is not found in external api", date, homeTeamID, awayTeamID,
+	)}
+}
+
+// matchFixtures returns fixtures of both teams starting close to the requested time.
+// When several fixtures are found, only the ones starting exactly at the requested time are kept.
+func matchFixtures(fixtures []client.Result, homeTeamID uint, awayTeamID uint, startsAt time.Time) []client.Result {
+	var candidates []client.Result
+	var exact []client.Result
+
+	for _, fixture := range fixtures {
+		if fixture.Teams.Home.ID != homeTeamID || fixture.Teams.Away.ID != awayTeamID {
+			continue
+		}
+
+		fixtureStartsAt, err := time.Parse(time.RFC3339, fixture.Fixture.Date)
+		if err != nil {
+			continue
+		}
+
+		difference := fixtureStartsAt.Sub(startsAt).Abs()
+		if difference > maxKickOffDifference {
+			continue
+		}
+
+		candidates = append(candidates, fixture)
+		if difference == 0 {
+			exact = append(exact, fixture)
+		}
+	}
+
+	if len(candidates) > 1 && len(exact) > 0 {
+		return exact
+	}
+
+	return candidates
+}
+
+func describeFixtures(fixtures []client.Result) string {
+	descriptions := make([]string, 0, len(fixtures))
+	for _, f := range fixtures {
+		descriptions = append(descriptions, fmt.Sprintf(
+			"fixture %d %s - %s at %s (%s)", f.Fixture.ID, f.Teams.Home.Name, f.Teams.Away.Name, f.Fixture.Date, f.League.Name,
+		))
+	}
+
+	return strings.Join(descriptions, "; ")
+}
@@ -0,0 +1,21 @@
+package service
+
+type FootballAPIQuotaService struct {
+	quotaTracker FootballAPIQuotaTracker
+}
+
+func NewFootballAPIQuotaService(quotaTracker FootballAPIQuotaTracker) *FootballAPIQuotaService {
+	return &FootballAPIQuotaService{quotaTracker: quotaTracker}
+}
+
+// Quota returns the last known football-api quota or nil when football-api is not called yet.
+func (s *FootballAPIQuotaService) Quota() *FootballAPIQuota {
+	quota := s.quotaTracker.Quota()
+	if quota == nil {
+		return nil
+	}
+
+	mapped := fromClientQuota(*quota)
+
+	return &mapped
+}
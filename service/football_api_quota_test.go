@@ -0,0 +1,43 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andrewshostak/result-service/client"
+	"github.com/andrewshostak/result-service/service"
+	"github.com/andrewshostak/result-service/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFootballAPIQuotaService_Quota(t *testing.T) {
+	t.Run("it should return nil when quota is unknown", func(t *testing.T) {
+		quotaTracker := mocks.NewFootballAPIQuotaTracker(t)
+		quotaTracker.On("Quota").Return(nil).Once()
+
+		s := service.NewFootballAPIQuotaService(quotaTracker)
+
+		assert.Nil(t, s.Quota())
+	})
+
+	t.Run("it should return quota without values of missing headers", func(t *testing.T) {
+		updatedAt := time.Now().UTC()
+		quotaTracker := mocks.NewFootballAPIQuotaTracker(t)
+		quotaTracker.On("Quota").Return(&client.Quota{
+			DailyLimit:      100,
+			DailyRemaining:  42,
+			MinuteLimit:     -1,
+			MinuteRemaining: -1,
+			UpdatedAt:       updatedAt,
+		}).Once()
+
+		s := service.NewFootballAPIQuotaService(quotaTracker)
+
+		dailyLimit, dailyRemaining := 100, 42
+		assert.Equal(t, &service.FootballAPIQuota{
+			DailyLimit:     &dailyLimit,
+			DailyRemaining: &dailyRemaining,
+			UpdatedAt:      updatedAt,
+		}, s.Quota())
+	})
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	healthCheckTimeout = 3 * time.Second
+	// footballAPIStatusTTL limits requests to football-api made by probes
+	footballAPIStatusTTL = time.Minute
+	// notifierMissedTicks is a number of notifier intervals without a tick or a run in progress after which the notifier is considered stuck
+	notifierMissedTicks = 3
+)
+
+type HealthService struct {
+	healthRepository         HealthRepository
+	notifierMonitor          NotifierMonitor
+	footballAPIStatusClient  FootballAPIStatusClient
+	clock                    Clock
+	expectedMigrationVersion uint
+
+	mutex                  sync.Mutex
+	footballAPICheckedAt   time.Time
+	footballAPIStatusError error
+}
+
+func NewHealthService(
+	healthRepository HealthRepository,
+	notifierMonitor NotifierMonitor,
+	footballAPIStatusClient FootballAPIStatusClient,
+	clock Clock,
+	expectedMigrationVersion uint,
+) *HealthService {
+	return &HealthService{
+		healthRepository:         healthRepository,
+		notifierMonitor:          notifierMonitor,
+		footballAPIStatusClient:  footballAPIStatusClient,
+		clock:                    clock,
+		expectedMigrationVersion: expectedMigrationVersion,
+	}
+}
+
+// Liveness checks only the state of the process itself, which can be fixed by a restart.
+func (s *HealthService) Liveness(_ context.Context) HealthReport {
+	return newHealthReport([]HealthCheck{s.checkNotifier()})
+}
+
+// Readiness checks dependencies. Football-api is not critical: matches which are already created don't need it
+// for subscriptions and notifications, and polling waits until it is available again.
+func (s *HealthService) Readiness(ctx context.Context) HealthReport {
+	return newHealthReport([]HealthCheck{
+		s.checkDatabase(ctx),
+		s.checkMigrations(ctx),
+		s.checkNotifier(),
+		s.checkNotifierRuns(),
+		s.checkFootballAPI(ctx),
+	})
+}
+
+func (s *HealthService) checkDatabase(ctx context.Context) HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	return newHealthCheck("database", true, s.healthRepository.Ping(ctx), nil)
+}
+
+func (s *HealthService) checkMigrations(ctx context.Context) HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	details := map[string]any{"expected_version": s.expectedMigrationVersion}
+
+	version, err := s.healthRepository.MigrationVersion(ctx)
+	if err != nil {
+		return newHealthCheck("migrations", true, fmt.Errorf("failed to get migration version: %w", err), details)
+	}
+
+	details["version"] = version.Version
+
+	switch {
+	case version.Dirty:
+		err = fmt.Errorf("migration %d failed and the database is dirty", version.Version)
+	case version.Version < s.expectedMigrationVersion:
+		err = fmt.Errorf("migration version %d is behind expected version %d", version.Version, s.expectedMigrationVersion)
+	}
+
+	return newHealthCheck("migrations", true, err, details)
+}
+
+func (s *HealthService) checkNotifier() HealthCheck {
+	lastTick := s.notifierMonitor.LastTick()
+	if lastTick.IsZero() {
+		return newHealthCheck("notifier", true, errors.New("notifier is not started"), nil)
+	}
+
+	details := map[string]any{"last_tick": lastTick.UTC()}
+
+	var err error
+	if sinceLastTick := s.clock.Now().Sub(lastTick); sinceLastTick > notifierMissedTicks*s.notifierMonitor.Interval() {
+		err = fmt.Errorf("notifier has not ticked for %s", sinceLastTick.Truncate(time.Second))
+	}
+
+	return newHealthCheck("notifier", true, err, details)
+}
+
+// checkNotifierRuns reports failing notifier runs. The loop keeps running with backoff, so it doesn't fail liveness.
+func (s *HealthService) checkNotifierRuns() HealthCheck {
+	failures, lastErr := s.notifierMonitor.Failures()
+	if failures == 0 {
+		return newHealthCheck("notifier_runs", false, nil, nil)
+	}
+
+	details := map[string]any{"consecutive_failures": failures}
+
+	return newHealthCheck("notifier_runs", false, fmt.Errorf("last notifier run failed: %w", lastErr), details)
+}
+
+// checkFootballAPI reuses the result of the previous check for footballAPIStatusTTL.
+func (s *HealthService) checkFootballAPI(ctx context.Context) HealthCheck {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.clock.Now()
+	if s.footballAPICheckedAt.IsZero() || now.Sub(s.footballAPICheckedAt) >= footballAPIStatusTTL {
+		ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		defer cancel()
+
+		s.footballAPIStatusError = s.footballAPIStatusClient.Status(ctx)
+		s.footballAPICheckedAt = now
+	}
+
+	return newHealthCheck("football_api", false, s.footballAPIStatusError, map[string]any{"checked_at": s.footballAPICheckedAt.UTC()})
+}
+
+func newHealthCheck(name string, critical bool, err error, details map[string]any) HealthCheck {
+	status := HealthStatusOK
+	if err != nil {
+		status = HealthStatusError
+	}
+
+	return HealthCheck{Name: name, Status: status, Critical: critical, Err: err, Details: details}
+}
+
+func newHealthReport(checks []HealthCheck) HealthReport {
+	status := HealthStatusOK
+	for _, check := range checks {
+		if check.Err == nil {
+			continue
+		}
+
+		if check.Critical {
+			return HealthReport{Status: HealthStatusUnavailable, Checks: checks}
+		}
+
+		status = HealthStatusDegraded
+	}
+
+	return HealthReport{Status: status, Checks: checks}
+}
@@ -0,0 +1,120 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/andrewshostak/result-service/repository"
+	"github.com/andrewshostak/result-service/service"
+	"github.com/andrewshostak/result-service/service/mocks"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHealthService_Readiness(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, time.January, 20, 15, 0, 0, 0, time.UTC)
+	expectedVersion := uint(20261019110000)
+
+	setup := func(t *testing.T) (*service.HealthService, *mocks.HealthRepository, *mocks.NotifierMonitor, *mocks.FootballAPIStatusClient, *clock.Fake) {
+		healthRepository := mocks.NewHealthRepository(t)
+		notifierMonitor := mocks.NewNotifierMonitor(t)
+		footballAPIStatusClient := mocks.NewFootballAPIStatusClient(t)
+		fakeClock := clock.NewFake(now)
+
+		notifierMonitor.On("Interval").Return(time.Minute).Maybe()
+
+		s := service.NewHealthService(healthRepository, notifierMonitor, footballAPIStatusClient, fakeClock, expectedVersion)
+
+		return s, healthRepository, notifierMonitor, footballAPIStatusClient, fakeClock
+	}
+
+	statuses := func(report service.HealthReport) map[string]string {
+		result := map[string]string{}
+		for _, check := range report.Checks {
+			result[check.Name] = check.Status
+		}
+
+		return result
+	}
+
+	t.Run("it should be ok when all checks pass and reuse football-api status", func(t *testing.T) {
+		s, healthRepository, notifierMonitor, footballAPIStatusClient, fakeClock := setup(t)
+
+		healthRepository.On("Ping", mock.Anything).Return(nil).Twice()
+		healthRepository.On("MigrationVersion", mock.Anything).Return(&repository.MigrationVersion{Version: expectedVersion}, nil).Twice()
+		notifierMonitor.On("LastTick").Return(now.Add(-time.Minute)).Twice()
+		notifierMonitor.On("Failures").Return(uint(0), nil).Twice()
+		footballAPIStatusClient.On("Status", mock.Anything).Return(nil).Once()
+
+		report := s.Readiness(ctx)
+		assert.Equal(t, service.HealthStatusOK, report.Status)
+		assert.Equal(t, map[string]string{"database": "ok", "migrations": "ok", "notifier": "ok", "notifier_runs": "ok", "football_api": "ok"}, statuses(report))
+
+		fakeClock.Advance(30 * time.Second)
+		assert.Equal(t, service.HealthStatusOK, s.Readiness(ctx).Status)
+	})
+
+	t.Run("it should be degraded when only football-api is unreachable", func(t *testing.T) {
+		s, healthRepository, notifierMonitor, footballAPIStatusClient, _ := setup(t)
+
+		healthRepository.On("Ping", mock.Anything).Return(nil).Once()
+		healthRepository.On("MigrationVersion", mock.Anything).Return(&repository.MigrationVersion{Version: expectedVersion}, nil).Once()
+		notifierMonitor.On("LastTick").Return(now).Once()
+		notifierMonitor.On("Failures").Return(uint(0), nil).Once()
+		errStatus := errors.New(gofakeit.Sentence(2))
+		footballAPIStatusClient.On("Status", mock.Anything).Return(errStatus).Once()
+
+		report := s.Readiness(ctx)
+		assert.Equal(t, service.HealthStatusDegraded, report.Status)
+		assert.Equal(t, "error", statuses(report)["football_api"])
+	})
+
+	t.Run("it should be unavailable when database is down, migrations are behind and notifier is stuck", func(t *testing.T) {
+		s, healthRepository, notifierMonitor, footballAPIStatusClient, _ := setup(t)
+
+		healthRepository.On("Ping", mock.Anything).Return(errors.New("connection refused")).Once()
+		healthRepository.On("MigrationVersion", mock.Anything).Return(&repository.MigrationVersion{Version: expectedVersion - 1}, nil).Once()
+		notifierMonitor.On("LastTick").Return(now.Add(-10 * time.Minute)).Once()
+		notifierMonitor.On("Failures").Return(uint(0), nil).Once()
+		footballAPIStatusClient.On("Status", mock.Anything).Return(nil).Once()
+
+		report := s.Readiness(ctx)
+		assert.Equal(t, service.HealthStatusUnavailable, report.Status)
+		assert.Equal(t, map[string]string{"database": "error", "migrations": "error", "notifier": "error", "notifier_runs": "ok", "football_api": "ok"}, statuses(report))
+		assert.EqualError(t, report.Checks[2].Err, "notifier has not ticked for 10m0s")
+	})
+
+	t.Run("it should be degraded when notifier runs fail", func(t *testing.T) {
+		s, healthRepository, notifierMonitor, footballAPIStatusClient, _ := setup(t)
+
+		healthRepository.On("Ping", mock.Anything).Return(nil).Once()
+		healthRepository.On("MigrationVersion", mock.Anything).Return(&repository.MigrationVersion{Version: expectedVersion}, nil).Once()
+		notifierMonitor.On("LastTick").Return(now).Once()
+		notifierMonitor.On("Failures").Return(uint(3), errors.New("connection refused")).Once()
+		footballAPIStatusClient.On("Status", mock.Anything).Return(nil).Once()
+
+		report := s.Readiness(ctx)
+		assert.Equal(t, service.HealthStatusDegraded, report.Status)
+		assert.Equal(t, "error", statuses(report)["notifier_runs"])
+		assert.EqualError(t, report.Checks[3].Err, "last notifier run failed: connection refused")
+		assert.Equal(t, map[string]any{"consecutive_failures": uint(3)}, report.Checks[3].Details)
+	})
+}
+
+func TestHealthService_Liveness(t *testing.T) {
+	t.Run("it should be unavailable when notifier is not started", func(t *testing.T) {
+		notifierMonitor := mocks.NewNotifierMonitor(t)
+		notifierMonitor.On("LastTick").Return(time.Time{}).Once()
+
+		s := service.NewHealthService(mocks.NewHealthRepository(t), notifierMonitor, mocks.NewFootballAPIStatusClient(t), clock.New(), 1)
+
+		report := s.Liveness(context.Background())
+		assert.Equal(t, service.HealthStatusUnavailable, report.Status)
+		assert.EqualError(t, report.Checks[0].Err, "notifier is not started")
+	})
+}
@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/andrewshostak/result-service/client"
 	"github.com/andrewshostak/result-service/errs"
+	loggerinternal "github.com/andrewshostak/result-service/logger"
 	"github.com/andrewshostak/result-service/repository"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const dateFormat = "2006-01-02"
@@ -20,11 +23,18 @@ type MatchService struct {
 	matchRepository              MatchRepository
 	footballAPIFixtureRepository FootballAPIFixtureRepository
 	footballAPIClient            FootballAPIClient
+	resultProviders              []ResultProvider
 	taskScheduler                TaskScheduler
+	resultPublisher              ResultPublisher
 	logger                       Logger
+	clock                        Clock
+	metrics                      Metrics
 	pollingMaxRetries            uint
 	pollingInterval              time.Duration
 	pollingFirstAttemptDelay     time.Duration
+	pollingMaxDuration           time.Duration
+	livePolling                  LivePolling
+	resultVerification           ResultVerification
 }
 
 func NewMatchService(
@@ -32,26 +42,42 @@ func NewMatchService(
 	matchRepository MatchRepository,
 	footballAPIFixtureRepository FootballAPIFixtureRepository,
 	footballAPIClient FootballAPIClient,
+	resultProviders []ResultProvider,
 	taskScheduler TaskScheduler,
+	resultPublisher ResultPublisher,
 	logger Logger,
+	clock Clock,
+	metrics Metrics,
 	pollingMaxRetries uint,
 	pollingInterval time.Duration,
 	pollingFirstAttemptDelay time.Duration,
+	pollingMaxDuration time.Duration,
+	livePolling LivePolling,
+	resultVerification ResultVerification,
 ) *MatchService {
 	return &MatchService{
 		aliasRepository:              aliasRepository,
 		matchRepository:              matchRepository,
 		footballAPIFixtureRepository: footballAPIFixtureRepository,
 		footballAPIClient:            footballAPIClient,
+		resultProviders:              resultProviders,
 		taskScheduler:                taskScheduler,
+		resultPublisher:              resultPublisher,
 		logger:                       logger,
+		clock:                        clock,
+		metrics:                      metrics,
 		pollingMaxRetries:            pollingMaxRetries,
 		pollingInterval:              pollingInterval,
 		pollingFirstAttemptDelay:     pollingFirstAttemptDelay,
+		pollingMaxDuration:           pollingMaxDuration,
+		livePolling:                  livePolling,
+		resultVerification:           resultVerification,
 	}
 }
 
 func (s *MatchService) Create(ctx context.Context, request CreateMatchRequest) (uint, error) {
+	logger := loggerinternal.WithRequestID(ctx, s.logger)
+
 	aliasHome, err := s.findAlias(ctx, request.AliasHome)
 	if err != nil {
 		return 0, fmt.Errorf("failed to find home team alias: %w", err)
@@ -77,29 +103,18 @@ func (s *MatchService) Create(ctx context.Context, request CreateMatchRequest) (
 		return 0, fmt.Errorf("unexpected error when getting a match: %w", err)
 	}
 
-	s.logger.Info().Str("alias_home", request.AliasHome).Str("alias_away", request.AliasAway).
+	logger.Info().Str("alias_home", request.AliasHome).Str("alias_away", request.AliasAway).
 		Msg("match is not found in the database. making an attempt to find it in external api")
 
-	date := request.StartsAt.UTC().Format(dateFormat)
-	season := uint(s.getSeason(request.StartsAt.UTC()))
-	response, err := s.footballAPIClient.SearchFixtures(ctx, client.FixtureSearch{
-		Season:   &season,
-		Timezone: time.UTC.String(),
-		Date:     &date,
-		TeamID:   &aliasHome.FootballApiTeam.ID,
-	})
+	result, err := s.findFixture(ctx, aliasHome.FootballApiTeam.ID, aliasAway.FootballApiTeam.ID, request.StartsAt.UTC())
 	if err != nil {
-		return 0, fmt.Errorf("unable to search fixtures in external api: %w", err)
-	}
-
-	if len(response.Response) < 1 {
-		return 0, errs.UnexpectedNumberOfItemsError{Message: fmt.Sprintf("fixture starting at %s with team id %d is not found in external api", date, aliasHome.FootballApiTeam.ID)}
+		return 0, fmt.Errorf("failed to find fixture: %w", err)
 	}
 
-	fixture := fromClientFootballAPIFixture(response.Response[0])
+	fixture := fromClientFootballAPIFixture(*result)
 
-	if fixture.Fixture.Status.Long == stateMatchFinished {
-		return 0, fmt.Errorf("%s: %w", fmt.Sprintf("status of the fixture with external id %d is %s", fixture.Fixture.ID, stateMatchFinished), errs.ErrIncorrectFixtureStatus)
+	if isFinished(fixture.Fixture.Status) {
+		return 0, fmt.Errorf("%s: %w", fmt.Sprintf("status of the fixture with external id %d is %s", fixture.Fixture.ID, fixture.Fixture.Status.Long), errs.ErrIncorrectFixtureStatus)
 	}
 
 	startsAt, err := time.Parse(time.RFC3339, fixture.Fixture.Date)
@@ -107,13 +122,14 @@ func (s *MatchService) Create(ctx context.Context, request CreateMatchRequest) (
 		return 0, fmt.Errorf("unable to parse received from external api fixture date %s: %w", fixture.Fixture.Date, err)
 	}
 
-	toCreate := repository.Match{HomeTeamID: aliasHome.TeamID, AwayTeamID: aliasAway.TeamID, StartsAt: startsAt}
+	traceID, spanID := traceIDs(ctx)
+	toCreate := repository.Match{HomeTeamID: aliasHome.TeamID, AwayTeamID: aliasAway.TeamID, StartsAt: startsAt, TraceID: traceID, SpanID: spanID}
 	created, err := s.matchRepository.Create(ctx, toCreate)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create match with team ids %d and %d starting at %s: %w", aliasHome.TeamID, aliasAway.TeamID, startsAt, err)
 	}
 
-	s.logger.Info().Uint("match_id", created.ID).Msg("match saved")
+	logger.Info().Uint("match_id", created.ID).Msg("match saved")
 
 	createdFixture, err := s.footballAPIFixtureRepository.Create(ctx, repository.FootballApiFixture{
 		ID:      fixture.Fixture.ID,
@@ -123,7 +139,7 @@ func (s *MatchService) Create(ctx context.Context, request CreateMatchRequest) (
 		return 0, fmt.Errorf("failed to create football api fixture with match id %d: %w", created.ID, err)
 	}
 
-	s.logger.Info().Uint("football_api_fixture_id", createdFixture.ID).Uint("match_id", created.ID).Msg("fixture saved")
+	logger.Info().Uint("football_api_fixture_id", createdFixture.ID).Uint("match_id", created.ID).Msg("fixture saved")
 
 	mappedMatch, err := fromRepositoryMatch(*created)
 	if err != nil {
@@ -140,12 +156,11 @@ func (s *MatchService) Create(ctx context.Context, request CreateMatchRequest) (
 		fixture:   *mappedFixture,
 		aliasHome: *aliasHome,
 		aliasAway: *aliasAway,
-		season:    season,
 	}); err != nil {
 		return 0, fmt.Errorf("failed to schedule match result aquiring: %w", err)
 	}
 
-	s.logger.Info().
+	logger.Info().
 		Uint("match_id", mappedMatch.ID).
 		Uint("football_api_fixture_id", mappedFixture.ID).
 		Str("alias_home", aliasHome.Alias).
@@ -193,7 +208,7 @@ func (s *MatchService) ScheduleMatchResultAcquiring(match Match) error {
 	}
 
 	params := matchResultTaskParams{
-		match:     Match{ID: match.ID, StartsAt: match.StartsAt},
+		match:     Match{ID: match.ID, StartsAt: match.StartsAt, TraceID: match.TraceID, SpanID: match.SpanID},
 		fixture:   match.FootballApiFixtures[0],
 		aliasHome: match.HomeTeam.Aliases[0],
 		aliasAway: match.AwayTeam.Aliases[0],
@@ -231,16 +246,25 @@ func (s *MatchService) scheduleMatchResultAcquiring(params matchResultTaskParams
 		aliasHome: params.aliasHome.Alias,
 		aliasAway: params.aliasAway.Alias,
 		startsAt:  params.match.StartsAt,
+		creator:   creatorSpanContext(params.match),
 	}
 
 	enrichLogWithMatchDetails(s.logger.Info(), fields).Msg("scheduling a task to acquire match result")
 
 	i := 1
 	ch := make(chan resultTaskChan)
-	search := client.FixtureSearch{Timezone: time.UTC.String(), ID: &params.fixture.ID}
+	query := ResultQuery{
+		Fixture: Data{
+			Fixture: Fixture{ID: params.fixture.ID, Date: params.match.StartsAt.UTC().Format(time.RFC3339)},
+			Teams:   params.fixture.Teams,
+			Goals:   Goals{Home: params.fixture.Home, Away: params.fixture.Away},
+		},
+		HomeAliases: []string{params.aliasHome.Alias},
+		AwayAliases: []string{params.aliasAway.Alias},
+	}
 
 	key := getTaskKey(params.match.ID, params.fixture.ID)
-	err := s.taskScheduler.Schedule(key, s.getTaskFunc(i, ch, search, fields), s.pollingInterval, params.match.StartsAt.Add(s.pollingFirstAttemptDelay))
+	err := s.taskScheduler.ScheduleOnce(key, s.getTaskFunc(key, i, ch, query, fields), params.match.StartsAt.Add(s.firstAttemptDelay(params.fixture.Round)))
 	if err != nil {
 		return fmt.Errorf("failed to schedule a task for match id %d: %w", fields.matchID, err)
 	}
@@ -250,62 +274,133 @@ func (s *MatchService) scheduleMatchResultAcquiring(params matchResultTaskParams
 	return nil
 }
 
-// getSeason returns current year if current time is after June 3, otherwise previous year
-func (s *MatchService) getSeason(startsAt time.Time) int {
-	seasonBound := time.Date(startsAt.Year(), 6, 3, 0, 0, 0, 0, time.UTC)
+// getTaskFunc returns a task which schedules itself again until the match result is received.
+// The delay between attempts depends on the match status, see nextAttemptDelay.
+func (s *MatchService) getTaskFunc(key string, i int, ch chan<- resultTaskChan, query ResultQuery, matchDetails matchLogFields) func(c context.Context) {
+	var task func(c context.Context)
+	task = func(c context.Context) {
+		c, span := tracer.Start(c, "poll match result", withCreatorLink(matchDetails.creator),
+			trace.WithAttributes(attribute.Int64("match.id", int64(matchDetails.matchID)), attribute.Int("attempt", i)))
+		defer span.End()
 
-	if startsAt.After(seasonBound) {
-		return startsAt.Year()
-	}
+		enrichLogWithMatchDetails(s.logger.Info(), matchDetails).Msg(fmt.Sprintf("making an attempt %d to get match result", i))
 
-	return startsAt.AddDate(-1, 0, 0).Year()
-}
+		fixture, provider, err := s.getResult(c, query, matchDetails)
+		if err == nil {
+			enrichLogWithMatchDetails(s.logger.Debug(), matchDetails).
+				Str("provider", provider).
+				Str("status", fixture.Fixture.Status.Short).
+				Uint("home", fixture.Goals.Home).
+				Uint("away", fixture.Goals.Away).
+				Msg("match result received from provider")
+		}
 
-func (s *MatchService) getTaskFunc(i int, ch chan<- resultTaskChan, search client.FixtureSearch, matchDetails matchLogFields) func(c context.Context) {
-	return func(c context.Context) {
-		enrichLogWithMatchDetails(s.logger.Info(), matchDetails).Msg(fmt.Sprintf("making an attempt %d to get match result", i))
+		// after the max polling duration these attempts are counted as errors
+		if unavailable(err) && s.uncountedAttemptAllowed(matchDetails) {
+			enrichLogWithMatchDetails(s.logger.Warn(), matchDetails).Err(err).Msg("result providers are unavailable. the attempt is not counted")
+			s.metrics.PollingAttempt(pollingOutcomeUnavailable)
+			s.scheduleNextAttempt(key, task, s.pollingInterval, ch, matchDetails)
+			return
+		}
+
+		if err != nil && (errors.Is(err, errs.ErrShuttingDown) || c.Err() != nil) {
+			enrichLogWithMatchDetails(s.logger.Warn(), matchDetails).Err(err).Msg("attempt to get match result is interrupted by shutdown. it is rescheduled on start")
+			return
+		}
 
-		response, err := s.footballAPIClient.SearchFixtures(c, search)
 		if err != nil {
-			enrichLogWithMatchDetails(s.logger.Error(), matchDetails).Err(err).Msg("received error when searching fixtures for match. cancelling")
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "failed to get match result")
+			enrichLogWithMatchDetails(s.logger.Error(), matchDetails).Err(err).Msg("received error when getting match result from all providers")
+			s.metrics.PollingAttempt(pollingOutcomeError)
 			i++
 
 			if s.retriesLimitReached(i) {
 				s.writeError(matchDetails, ch)
+				return
 			}
+
+			s.scheduleNextAttempt(key, task, s.pollingInterval, ch, matchDetails)
 			return
 		}
 
-		if len(response.Response) < 1 {
-			enrichLogWithMatchDetails(s.logger.Error(), matchDetails).Msg("unexpected length of fixture search result")
-			i++
+		if !isFinished(fixture.Fixture.Status) {
+			delay, live := s.nextAttemptDelay(fixture.Fixture.Status)
+			event := enrichLogWithMatchDetails(s.logger.Info(), matchDetails).
+				Str("status", fixture.Fixture.Status.Short).
+				Dur("next_attempt_in", delay)
+			if fixture.Fixture.Status.Elapsed != nil {
+				event = event.Uint("elapsed", *fixture.Fixture.Status.Elapsed)
+			}
+			event.Msg("match status is not finished")
+
+			// the match in progress will be finished, so only attempts with other statuses (e.g. suspended) are counted.
+			// after the live polling max duration the match is considered stuck, and its attempts are counted too
+			if live && s.livePollingAllowed(matchDetails) {
+				s.metrics.PollingAttempt(pollingOutcomeInProgress)
+			} else {
+				s.metrics.PollingAttempt(pollingOutcomeNotFinished)
+				i++
+			}
 
 			if s.retriesLimitReached(i) {
 				s.writeError(matchDetails, ch)
+				return
 			}
+
+			s.scheduleNextAttempt(key, task, delay, ch, matchDetails)
 			return
 		}
 
-		fixture := fromClientFootballAPIFixture(response.Response[0])
-
-		if fixture.Fixture.Status.Long != stateMatchFinished {
-			enrichLogWithMatchDetails(s.logger.Info(), matchDetails).Str("status", fixture.Fixture.Status.Long).
-				Msg("match status is not finished")
-			i++
+		s.metrics.PollingAttempt(pollingOutcomeFinished)
 
-			if s.retriesLimitReached(i) {
-				s.writeError(matchDetails, ch)
+		switch s.resultVerification.Mode {
+		case ResultVerificationProvider:
+			if !s.verifyWithProviders(c, &i, ch, query, *fixture, provider, matchDetails) {
+				s.scheduleNextAttempt(key, task, s.pollingInterval, ch, matchDetails)
 			}
-			return
+		case ResultVerificationDelayed:
+			s.scheduleDelayedVerification(key, &i, ch, query, *fixture, provider, matchDetails)
+		default:
+			s.writeResult(ch, *fixture, provider, matchDetails)
 		}
+	}
+
+	return task
+}
 
-		enrichLogWithMatchDetails(s.logger.Info(), matchDetails).
-			Uint("home", fixture.Goals.Home).
-			Uint("away", fixture.Goals.Away).
-			Msg("match result received successfully. cancelling the task")
-		ch <- resultTaskChan{fixture: &fixture}
-		close(ch)
+func (s *MatchService) scheduleNextAttempt(key string, task func(c context.Context), delay time.Duration, ch chan<- resultTaskChan, matchDetails matchLogFields) {
+	err := s.taskScheduler.ScheduleOnce(key, task, s.clock.Now().Add(delay))
+	if errors.Is(err, errs.ErrShuttingDown) {
+		enrichLogWithMatchDetails(s.logger.Warn(), matchDetails).Msg("next attempt to get match result is not scheduled because of shutdown. it is rescheduled on start")
+		return
+	}
+
+	if err != nil {
+		enrichLogWithMatchDetails(s.logger.Error(), matchDetails).Err(err).Msg("failed to schedule next attempt to get match result")
+		s.writeError(matchDetails, ch)
+	}
+}
+
+// getResult requests the result from providers in their order. The next provider is used only when the previous one fails.
+func (s *MatchService) getResult(ctx context.Context, query ResultQuery, matchDetails matchLogFields) (*Data, string, error) {
+	var providerErrors []error
+	for _, provider := range s.resultProviders {
+		fixture, err := provider.Result(ctx, query)
+		if err == nil {
+			return fixture, provider.ID(), nil
+		}
+
+		enrichLogWithMatchDetails(s.logger.Error(), matchDetails).Err(err).Str("provider", provider.ID()).
+			Msg("failed to get match result from provider")
+		providerErrors = append(providerErrors, fmt.Errorf("%s: %w", provider.ID(), err))
+	}
+
+	if len(providerErrors) == 0 {
+		return nil, "", errors.New("result providers are not configured")
 	}
+
+	return nil, "", errors.Join(providerErrors...)
 }
 
 func (s *MatchService) handleTaskResult(
@@ -316,6 +411,11 @@ func (s *MatchService) handleTaskResult(
 	matchDetails matchLogFields,
 ) {
 	result := <-ch
+
+	ctx, span := tracer.Start(ctx, "save match result", withCreatorLink(matchDetails.creator),
+		trace.WithAttributes(attribute.Int64("match.id", int64(matchID))))
+	defer span.End()
+
 	key := getTaskKey(matchID, fixtureID)
 	s.taskScheduler.Cancel(key)
 
@@ -333,19 +433,47 @@ func (s *MatchService) handleTaskResult(
 		return
 	}
 
-	if _, err := s.footballAPIFixtureRepository.Update(ctx, fixtureID, toRepositoryFootballAPIFixtureData(*result.fixture)); err != nil {
-		enrichLogWithMatchDetails(s.logger.Error(), matchDetails).Err(err).Msg("failed to update fixture")
-		return
+	resultStatus := repository.Successful
+	if result.needsReview {
+		resultStatus = repository.NeedsReview
 	}
 
-	if _, err := s.matchRepository.Update(ctx, matchID, repository.Successful); err != nil {
+	// the fixture, the result status and notifications of subscribers are saved together. When saving fails,
+	// the match stays scheduled and its result is acquired again after restart
+	err := s.matchRepository.SaveResultInTrx(ctx, repository.MatchResult{
+		MatchID:      matchID,
+		FixtureID:    fixtureID,
+		Data:         toRepositoryFootballAPIFixtureData(*result.fixture),
+		Provider:     result.provider,
+		ResultStatus: resultStatus,
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to save match result")
 		enrichLogWithMatchDetails(s.logger.Error(), matchDetails).Err(err).
-			Str("result_status", string(repository.Successful)).
-			Msg("failed to update result status")
+			Str("result_status", string(resultStatus)).
+			Msg("failed to save match result")
 		return
 	}
 
-	return
+	if resultStatus != repository.Successful {
+		return
+	}
+
+	// subscribers are notified right away, the periodic notifier sweep delivers the result when publishing fails
+	if err := s.resultPublisher.PublishMatchResult(ctx, matchID); err != nil {
+		enrichLogWithMatchDetails(s.logger.Warn(), matchDetails).Err(err).Msg("failed to publish match result. subscribers are notified by the next sweep")
+	}
+}
+
+// uncountedAttemptAllowed reports whether an attempt may be made without counting it in the retries limit.
+// Such attempts are made only within the max polling duration from the match start, so the polling doesn't last forever.
+func (s *MatchService) uncountedAttemptAllowed(matchDetails matchLogFields) bool {
+	return s.clock.Now().Before(matchDetails.startsAt.Add(s.pollingMaxDuration))
+}
+
+func unavailable(err error) bool {
+	return errors.Is(err, errs.ErrCircuitOpen) || errors.Is(err, errs.ErrQuotaExhausted)
 }
 
 func (s *MatchService) retriesLimitReached(i int) bool {
@@ -358,6 +486,16 @@ func (s *MatchService) writeError(matchDetails matchLogFields, ch chan<- resultT
 	ch <- resultTaskChan{error: errors.New(errMessage)}
 }
 
+func (s *MatchService) writeResult(ch chan<- resultTaskChan, fixture Data, provider string, matchDetails matchLogFields) {
+	enrichLogWithMatchDetails(s.logger.Info(), matchDetails).
+		Uint("home", fixture.Goals.Home).
+		Uint("away", fixture.Goals.Away).
+		Str("provider", provider).
+		Msg("match result received successfully. cancelling the task")
+	ch <- resultTaskChan{fixture: &fixture, provider: provider}
+	close(ch)
+}
+
 func getTaskKey(matchID uint, fixtureID uint) string {
 	return fmt.Sprintf("%d-%d", matchID, fixtureID)
 }
@@ -374,6 +512,8 @@ type matchLogFields struct {
 	aliasHome string
 	aliasAway string
 	startsAt  time.Time
+	// creator is the span context of the request which created the match, background spans are linked to it
+	creator trace.SpanContext
 }
 
 type matchResultTaskParams struct {
@@ -381,10 +521,11 @@ type matchResultTaskParams struct {
 	fixture   FootballAPIFixture
 	aliasHome Alias
 	aliasAway Alias
-	season    uint
 }
 
 type resultTaskChan struct {
-	fixture *Data
-	error   error
+	fixture     *Data
+	provider    string
+	needsReview bool
+	error       error
 }
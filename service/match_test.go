@@ -7,37 +7,23 @@ import (
 	"testing"
 	"time"
 
+	"github.com/andrewshostak/result-service/client"
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/andrewshostak/result-service/errs"
+	"github.com/andrewshostak/result-service/metrics"
 	"github.com/andrewshostak/result-service/repository"
+	"github.com/andrewshostak/result-service/scheduler"
 	"github.com/andrewshostak/result-service/service"
 	"github.com/andrewshostak/result-service/service/mocks"
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/jackc/pgtype"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestMatchService_List(t *testing.T) {
-	aliasRepository := mocks.NewAliasRepository(t)
 	matchRepository := mocks.NewMatchRepository(t)
-	footballAPIFixtureRepository := mocks.NewFootballAPIFixtureRepository(t)
-	footballAPIClient := mocks.NewFootballAPIClient(t)
-	taskScheduler := mocks.NewTaskScheduler(t)
-	logger := mocks.NewLogger(t)
-
-	pollingMaxRetries := uint(5)
-	pollingInterval := 15 * time.Minute
-	pollingFirstAttemptDelay := 115 * time.Minute
-
-	ms := service.NewMatchService(
-		aliasRepository,
-		matchRepository,
-		footballAPIFixtureRepository,
-		footballAPIClient,
-		taskScheduler,
-		logger,
-		pollingMaxRetries,
-		pollingInterval,
-		pollingFirstAttemptDelay,
-	)
+	ms := newMatchService(t, matchServiceSetup{matchRepository: matchRepository})
 
 	ctx := context.Background()
 	status := "scheduled"
@@ -61,6 +47,574 @@ func TestMatchService_List(t *testing.T) {
 	})
 }
 
+func TestMatchService_Create(t *testing.T) {
+	ctx := context.Background()
+	startsAt := time.Date(2024, time.January, 20, 15, 0, 0, 0, time.UTC)
+	request := service.CreateMatchRequest{StartsAt: startsAt, AliasHome: "Arsenal", AliasAway: "Chelsea"}
+
+	homeAlias := repository.Alias{TeamID: 1, Alias: request.AliasHome, FootballApiTeam: &repository.FootballApiTeam{ID: 42, TeamID: 1}}
+	awayAlias := repository.Alias{TeamID: 2, Alias: request.AliasAway, FootballApiTeam: &repository.FootballApiTeam{ID: 49, TeamID: 2}}
+
+	fixture := func(id uint, awayTeamID uint, date string) client.Result {
+		return client.Result{
+			Fixture: client.Fixture{ID: id, Date: date, Status: client.Status{Short: "NS", Long: "Not Started"}},
+			League:  client.FixtureLeague{Name: "Premier League", Round: "Regular Season - 21"},
+			Teams:   client.Teams{Home: client.Team{ID: 42, Name: "Arsenal"}, Away: client.Team{ID: awayTeamID, Name: "Chelsea"}},
+		}
+	}
+	search := func(season uint) client.FixtureSearch {
+		date := "2024-01-20"
+		homeTeamID := uint(42)
+		return client.FixtureSearch{Season: &season, Timezone: time.UTC.String(), Date: &date, TeamID: &homeTeamID}
+	}
+
+	setup := func(t *testing.T) (*service.MatchService, *mocks.FootballAPIClient, *mocks.MatchRepository, *mocks.FootballAPIFixtureRepository, *mocks.TaskScheduler) {
+		aliasRepository := mocks.NewAliasRepository(t)
+		matchRepository := mocks.NewMatchRepository(t)
+		footballAPIFixtureRepository := mocks.NewFootballAPIFixtureRepository(t)
+		footballAPIClient := mocks.NewFootballAPIClient(t)
+		taskScheduler := mocks.NewTaskScheduler(t)
+		logger := mocks.NewLogger(t)
+
+		logger.On("Info").Return(nil)
+		aliasRepository.On("Find", ctx, request.AliasHome).Return(&homeAlias, nil).Once()
+		aliasRepository.On("Find", ctx, request.AliasAway).Return(&awayAlias, nil).Once()
+		matchRepository.On("One", ctx, repository.Match{StartsAt: startsAt, HomeTeamID: 1, AwayTeamID: 2}).
+			Return(nil, errs.MatchNotFoundError{Message: gofakeit.Sentence(2)}).Once()
+
+		ms := newMatchService(t, matchServiceSetup{
+			aliasRepository:              aliasRepository,
+			matchRepository:              matchRepository,
+			footballAPIFixtureRepository: footballAPIFixtureRepository,
+			footballAPIClient:            footballAPIClient,
+			taskScheduler:                taskScheduler,
+			logger:                       logger,
+		})
+
+		return ms, footballAPIClient, matchRepository, footballAPIFixtureRepository, taskScheduler
+	}
+
+	t.Run("it should try adjacent seasons and skip fixtures of other away team", func(t *testing.T) {
+		ms, footballAPIClient, matchRepository, footballAPIFixtureRepository, taskScheduler := setup(t)
+
+		youthFixture := fixture(100, 50, "2024-01-20T12:00:00Z")
+		found := fixture(101, 49, "2024-01-20T15:00:00Z")
+		footballAPIClient.On("SearchFixtures", ctx, search(2023)).Return(&client.FixturesResponse{Response: []client.Result{youthFixture}}, nil).Once()
+		footballAPIClient.On("SearchFixtures", ctx, search(2022)).Return(&client.FixturesResponse{}, nil).Once()
+		footballAPIClient.On("SearchFixtures", ctx, search(2024)).Return(&client.FixturesResponse{Response: []client.Result{found}}, nil).Once()
+
+		matchRepository.On("Create", ctx, repository.Match{HomeTeamID: 1, AwayTeamID: 2, StartsAt: startsAt}).
+			Return(&repository.Match{ID: 7, HomeTeamID: 1, AwayTeamID: 2, StartsAt: startsAt}, nil).Once()
+		footballAPIFixtureRepository.On("Create", ctx, repository.FootballApiFixture{ID: found.Fixture.ID, MatchID: 7}, mock.Anything).
+			Return(&repository.FootballApiFixture{ID: found.Fixture.ID, MatchID: 7, Data: pgtype.JSONB{Bytes: []byte(`{}`), Status: pgtype.Present}}, nil).Once()
+		taskScheduler.On("ScheduleOnce", "7-101", mock.Anything, startsAt.Add(115*time.Minute)).Return(nil).Once()
+		matchRepository.On("Update", ctx, uint(7), repository.Scheduled).Return(&repository.Match{ID: 7}, nil).Once()
+
+		id, err := ms.Create(ctx, request)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(7), id)
+	})
+
+	t.Run("it should return ambiguous fixture error when several fixtures of both teams are found", func(t *testing.T) {
+		ms, footballAPIClient, _, _, _ := setup(t)
+
+		footballAPIClient.On("SearchFixtures", ctx, search(2023)).Return(&client.FixturesResponse{Response: []client.Result{
+			fixture(100, 49, "2024-01-20T14:00:00Z"),
+			fixture(101, 49, "2024-01-20T16:00:00Z"),
+		}}, nil).Once()
+
+		id, err := ms.Create(ctx, request)
+		assert.ErrorAs(t, err, &errs.AmbiguousFixtureError{})
+		assert.ErrorContains(t, err, "fixture 100 Arsenal - Chelsea at 2024-01-20T14:00:00Z")
+		assert.ErrorContains(t, err, "fixture 101 Arsenal - Chelsea at 2024-01-20T16:00:00Z")
+		assert.Zero(t, id)
+	})
+
+	t.Run("it should return incorrect fixture status error when the match is finished after penalties", func(t *testing.T) {
+		ms, footballAPIClient, _, _, _ := setup(t)
+
+		finished := fixture(101, 49, "2024-01-20T15:00:00Z")
+		finished.Fixture.Status = client.Status{Short: "PEN", Long: "Match Finished After Penalty"}
+		footballAPIClient.On("SearchFixtures", ctx, search(2023)).Return(&client.FixturesResponse{Response: []client.Result{finished}}, nil).Once()
+
+		id, err := ms.Create(ctx, request)
+		assert.ErrorIs(t, err, errs.ErrIncorrectFixtureStatus)
+		assert.Zero(t, id)
+	})
+}
+
+func TestMatchService_ScheduleMatchResultAcquiring(t *testing.T) {
+	t.Run("it should poll the match in progress until it is finished and save the result", func(t *testing.T) {
+		matchRepository := mocks.NewMatchRepository(t)
+		footballAPIFixtureRepository := mocks.NewFootballAPIFixtureRepository(t)
+		resultProvider := mocks.NewResultProvider(t)
+		resultPublisher := mocks.NewResultPublisher(t)
+		logger := mocks.NewLogger(t)
+
+		startsAt := time.Date(2024, time.January, 20, 15, 0, 0, 0, time.UTC)
+		fakeClock := clock.NewFake(startsAt)
+
+		logger.On("Info").Return(nil)
+		logger.On("Debug").Return(nil)
+		resultProvider.On("ID").Return("football-api")
+
+		ms := newMatchService(t, matchServiceSetup{
+			matchRepository:              matchRepository,
+			footballAPIFixtureRepository: footballAPIFixtureRepository,
+			resultProviders:              []service.ResultProvider{resultProvider},
+			taskScheduler:                scheduler.NewTaskScheduler(fakeClock),
+			resultPublisher:              resultPublisher,
+			logger:                       logger,
+			clock:                        fakeClock,
+		})
+
+		elapsed := uint(80)
+		inProgress := service.Data{Fixture: service.Fixture{ID: 101, Status: service.Status{Short: "2H", Long: "Second Half", Elapsed: &elapsed}}, Goals: service.Goals{Home: 1}}
+		finished := service.Data{Fixture: service.Fixture{ID: 101, Status: service.Status{Short: "FT", Long: "Match Finished"}}, Goals: service.Goals{Home: 2, Away: 1}}
+
+		err := ms.ScheduleMatchResultAcquiring(service.Match{
+			ID:                  7,
+			StartsAt:            startsAt,
+			FootballApiFixtures: []service.FootballAPIFixture{{ID: 101, Round: "Regular Season - 21"}},
+			HomeTeam:            &service.Team{ID: 1, Aliases: []service.Alias{{TeamID: 1, Alias: "Arsenal"}}},
+			AwayTeam:            &service.Team{ID: 2, Aliases: []service.Alias{{TeamID: 2, Alias: "Chelsea"}}},
+		})
+		assert.NoError(t, err)
+
+		// nothing is requested before the first attempt delay
+		fakeClock.Advance(114 * time.Minute)
+
+		resultProvider.On("Result", mock.Anything, mock.Anything).Return(&inProgress, nil).Once()
+		fakeClock.Advance(time.Minute)
+
+		// the next attempt is at the 85th minute
+		fakeClock.Advance(4 * time.Minute)
+
+		saved := make(chan struct{})
+		resultProvider.On("Result", mock.Anything, mock.Anything).Return(&finished, nil).Once()
+		matchRepository.On("SaveResultInTrx", mock.Anything, mock.MatchedBy(func(result repository.MatchResult) bool {
+			return result.MatchID == 7 && result.FixtureID == 101 && result.Provider == "football-api" &&
+				result.ResultStatus == repository.Successful && result.Data.Goals == repository.Goals{Home: 2, Away: 1}
+		})).Return(nil).Once()
+		resultPublisher.On("PublishMatchResult", mock.Anything, uint(7)).Return(nil).Once().
+			Run(func(_ mock.Arguments) { close(saved) })
+		fakeClock.Advance(time.Minute)
+
+		select {
+		case <-saved:
+		case <-time.After(time.Second):
+			t.Fatal("match result is not saved")
+		}
+	})
+}
+
+func TestMatchService_ScheduleMatchResultAcquiring_Verification(t *testing.T) {
+	startsAt := time.Date(2024, time.January, 20, 15, 0, 0, 0, time.UTC)
+	finished := func(home uint, away uint) *service.Data {
+		return &service.Data{Fixture: service.Fixture{ID: 101, Status: service.Status{Short: "FT", Long: "Match Finished"}}, Goals: service.Goals{Home: home, Away: away}}
+	}
+	elapsed := uint(90)
+	inProgress := &service.Data{Fixture: service.Fixture{ID: 101, Status: service.Status{Short: "2H", Long: "Second Half", Elapsed: &elapsed}}, Goals: service.Goals{Home: 2, Away: 1}}
+
+	type setup struct {
+		clock           *clock.Fake
+		matchRepository *mocks.MatchRepository
+		resultPublisher *mocks.ResultPublisher
+		saved           chan repository.MatchResult
+	}
+
+	// schedule starts polling of the match by the service with the providers, the first attempt is made in 115 minutes
+	schedule := func(t *testing.T, verification service.ResultVerification, providers ...service.ResultProvider) setup {
+		matchRepository := mocks.NewMatchRepository(t)
+		resultPublisher := mocks.NewResultPublisher(t)
+		logger := mocks.NewLogger(t)
+		fakeClock := clock.NewFake(startsAt)
+
+		for _, level := range []string{"Debug", "Info", "Warn", "Error"} {
+			logger.On(level).Return(nil).Maybe()
+		}
+
+		saved := make(chan repository.MatchResult, 1)
+		matchRepository.On("SaveResultInTrx", mock.Anything, mock.Anything).Return(nil).Once().
+			Run(func(args mock.Arguments) { saved <- args.Get(1).(repository.MatchResult) })
+
+		ms := newMatchService(t, matchServiceSetup{
+			matchRepository:   matchRepository,
+			resultProviders:   providers,
+			taskScheduler:     scheduler.NewTaskScheduler(fakeClock),
+			resultPublisher:   resultPublisher,
+			logger:            logger,
+			clock:             fakeClock,
+			pollingMaxRetries: 2,
+			verification:      verification,
+		})
+
+		err := ms.ScheduleMatchResultAcquiring(service.Match{
+			ID:                  7,
+			StartsAt:            startsAt,
+			FootballApiFixtures: []service.FootballAPIFixture{{ID: 101, Round: "Regular Season - 21"}},
+			HomeTeam:            &service.Team{ID: 1, Aliases: []service.Alias{{TeamID: 1, Alias: "Arsenal"}}},
+			AwayTeam:            &service.Team{ID: 2, Aliases: []service.Alias{{TeamID: 2, Alias: "Chelsea"}}},
+		})
+		assert.NoError(t, err)
+
+		return setup{clock: fakeClock, matchRepository: matchRepository, resultPublisher: resultPublisher, saved: saved}
+	}
+
+	waitForSaved := func(t *testing.T, saved <-chan repository.MatchResult) repository.MatchResult {
+		select {
+		case result := <-saved:
+			return result
+		case <-time.After(time.Second):
+			t.Fatal("match result is not saved")
+			return repository.MatchResult{}
+		}
+	}
+
+	provider := func(t *testing.T, id string) *mocks.ResultProvider {
+		p := mocks.NewResultProvider(t)
+		p.On("ID").Return(id).Maybe()
+		return p
+	}
+
+	t.Run("it should save the result confirmed by another provider", func(t *testing.T) {
+		footballAPI, resultFeed := provider(t, "football-api"), provider(t, "result-feed")
+		footballAPI.On("Result", mock.Anything, mock.Anything).Return(finished(2, 1), nil).Once()
+		resultFeed.On("Result", mock.Anything, mock.Anything).Return(finished(2, 1), nil).Once()
+
+		s := schedule(t, service.ResultVerification{Mode: service.ResultVerificationProvider}, footballAPI, resultFeed)
+		s.resultPublisher.On("PublishMatchResult", mock.Anything, uint(7)).Return(nil).Once()
+		s.clock.Advance(115 * time.Minute)
+
+		result := waitForSaved(t, s.saved)
+		assert.Equal(t, repository.Successful, result.ResultStatus)
+		assert.Equal(t, "football-api", result.Provider)
+	})
+
+	t.Run("it should send the result for review when another provider returns other goals", func(t *testing.T) {
+		footballAPI, resultFeed := provider(t, "football-api"), provider(t, "result-feed")
+		footballAPI.On("Result", mock.Anything, mock.Anything).Return(finished(2, 1), nil).Once()
+		resultFeed.On("Result", mock.Anything, mock.Anything).Return(finished(2, 2), nil).Once()
+
+		s := schedule(t, service.ResultVerification{Mode: service.ResultVerificationProvider}, footballAPI, resultFeed)
+		s.clock.Advance(115 * time.Minute)
+
+		result := waitForSaved(t, s.saved)
+		assert.Equal(t, repository.NeedsReview, result.ResultStatus)
+		assert.Equal(t, repository.Goals{Home: 2, Away: 1}, result.Data.Goals)
+	})
+
+	t.Run("it should read the result again after the delay and save it when it is the same", func(t *testing.T) {
+		footballAPI := provider(t, "football-api")
+		footballAPI.On("Result", mock.Anything, mock.Anything).Return(finished(2, 1), nil).Once()
+
+		s := schedule(t, service.ResultVerification{Mode: service.ResultVerificationDelayed, Delay: 5 * time.Minute}, footballAPI)
+		s.clock.Advance(115 * time.Minute)
+
+		// the provider lags behind, so the second read is repeated
+		footballAPI.On("Result", mock.Anything, mock.Anything).Return(inProgress, nil).Once()
+		s.clock.Advance(5 * time.Minute)
+
+		footballAPI.On("Result", mock.Anything, mock.Anything).Return(finished(2, 1), nil).Once()
+		s.resultPublisher.On("PublishMatchResult", mock.Anything, uint(7)).Return(nil).Once()
+		s.clock.Advance(5 * time.Minute)
+
+		result := waitForSaved(t, s.saved)
+		assert.Equal(t, repository.Successful, result.ResultStatus)
+	})
+
+	t.Run("it should send the result for review when the second read returns other goals", func(t *testing.T) {
+		footballAPI := provider(t, "football-api")
+		footballAPI.On("Result", mock.Anything, mock.Anything).Return(finished(2, 1), nil).Once()
+		footballAPI.On("Result", mock.Anything, mock.Anything).Return(finished(3, 1), nil).Once()
+
+		s := schedule(t, service.ResultVerification{Mode: service.ResultVerificationDelayed, Delay: 5 * time.Minute}, footballAPI)
+		s.clock.Advance(120 * time.Minute)
+
+		result := waitForSaved(t, s.saved)
+		assert.Equal(t, repository.NeedsReview, result.ResultStatus)
+		assert.Equal(t, repository.Goals{Home: 2, Away: 1}, result.Data.Goals)
+	})
+
+	t.Run("it should send the result for review when the second read is not finished within the retries limit", func(t *testing.T) {
+		footballAPI := provider(t, "football-api")
+		footballAPI.On("Result", mock.Anything, mock.Anything).Return(finished(2, 1), nil).Once()
+		footballAPI.On("Result", mock.Anything, mock.Anything).Return(inProgress, nil).Twice()
+
+		s := schedule(t, service.ResultVerification{Mode: service.ResultVerificationDelayed, Delay: 5 * time.Minute}, footballAPI)
+		s.clock.Advance(125 * time.Minute)
+
+		result := waitForSaved(t, s.saved)
+		assert.Equal(t, repository.NeedsReview, result.ResultStatus)
+	})
+}
+
+func TestMatchService_ScheduleMatchResultAcquiring_Polling(t *testing.T) {
+	startsAt := time.Date(2024, time.January, 20, 15, 0, 0, 0, time.UTC)
+
+	type setup struct {
+		clock           *clock.Fake
+		provider        *mocks.ResultProvider
+		resultPublisher *mocks.ResultPublisher
+		saved           chan repository.MatchResult
+		failed          chan struct{}
+	}
+
+	// schedule starts polling of the match in the round, the first attempt is made in 115 minutes (145 for knockout rounds).
+	// Next attempts are made every 15 minutes, live attempts are not counted within 3 hours from the match start
+	schedule := func(t *testing.T, round string, pollingMaxDuration time.Duration) setup {
+		matchRepository := mocks.NewMatchRepository(t)
+		resultPublisher := mocks.NewResultPublisher(t)
+		logger := mocks.NewLogger(t)
+		provider := mocks.NewResultProvider(t)
+		fakeClock := clock.NewFake(startsAt)
+
+		for _, level := range []string{"Debug", "Info", "Warn", "Error"} {
+			logger.On(level).Return(nil).Maybe()
+		}
+		provider.On("ID").Return("football-api").Maybe()
+
+		saved := make(chan repository.MatchResult, 1)
+		matchRepository.On("SaveResultInTrx", mock.Anything, mock.Anything).Return(nil).Maybe().
+			Run(func(args mock.Arguments) { saved <- args.Get(1).(repository.MatchResult) })
+		failed := make(chan struct{})
+		matchRepository.On("Update", mock.Anything, uint(7), repository.Error).Return(&repository.Match{}, nil).Maybe().
+			Run(func(_ mock.Arguments) { close(failed) })
+
+		ms := newMatchService(t, matchServiceSetup{
+			matchRepository:        matchRepository,
+			resultProviders:        []service.ResultProvider{provider},
+			taskScheduler:          scheduler.NewTaskScheduler(fakeClock),
+			resultPublisher:        resultPublisher,
+			logger:                 logger,
+			clock:                  fakeClock,
+			pollingMaxRetries:      2,
+			pollingMaxDuration:     pollingMaxDuration,
+			livePollingMaxDuration: 3 * time.Hour,
+		})
+
+		err := ms.ScheduleMatchResultAcquiring(service.Match{
+			ID:                  7,
+			StartsAt:            startsAt,
+			FootballApiFixtures: []service.FootballAPIFixture{{ID: 101, Round: round}},
+			HomeTeam:            &service.Team{ID: 1, Aliases: []service.Alias{{TeamID: 1, Alias: "Arsenal"}}},
+			AwayTeam:            &service.Team{ID: 2, Aliases: []service.Alias{{TeamID: 2, Alias: "Chelsea"}}},
+		})
+		assert.NoError(t, err)
+
+		return setup{clock: fakeClock, provider: provider, resultPublisher: resultPublisher, saved: saved, failed: failed}
+	}
+
+	status := func(short string, elapsed uint) *service.Data {
+		return &service.Data{Fixture: service.Fixture{ID: 101, Status: service.Status{Short: short, Elapsed: &elapsed}}, Goals: service.Goals{Home: 1, Away: 1}}
+	}
+
+	waitForFailed := func(t *testing.T, failed <-chan struct{}) {
+		select {
+		case <-failed:
+		case <-time.After(time.Second):
+			t.Fatal("match result status is not updated to error")
+		}
+	}
+
+	t.Run("it should make the first attempt later for knockout rounds", func(t *testing.T) {
+		tests := []struct {
+			round string
+			delay time.Duration
+		}{
+			{round: "Regular Season - 21", delay: 115 * time.Minute},
+			{round: "Group A - 2", delay: 115 * time.Minute},
+			{round: "Championship Round - 3", delay: 115 * time.Minute},
+			{round: "Relegation Round - 1", delay: 115 * time.Minute},
+			{round: "Round of 16", delay: 145 * time.Minute},
+			{round: "Quarter-finals", delay: 145 * time.Minute},
+			{round: "Final", delay: 145 * time.Minute},
+			{round: "Knockout Round Play-offs", delay: 145 * time.Minute},
+			{round: "Promotion Play-off - Semi-finals", delay: 145 * time.Minute},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.round, func(t *testing.T) {
+				s := schedule(t, tt.round, 24*time.Hour)
+				s.provider.On("Result", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("football api: %w", errs.ErrCircuitOpen))
+
+				s.clock.Advance(tt.delay - time.Second)
+				s.provider.AssertNumberOfCalls(t, "Result", 0)
+
+				s.clock.Advance(time.Second)
+				s.provider.AssertNumberOfCalls(t, "Result", 1)
+			})
+		}
+	})
+
+	t.Run("it should make the next attempt depending on the match status", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			status *service.Data
+			delay  time.Duration
+		}{
+			{name: "first half is polled at the regular interval", status: status("1H", 40), delay: 15 * time.Minute},
+			{name: "half time", status: status("HT", 45), delay: 10 * time.Minute},
+			{name: "second half is polled until its 85th minute", status: status("2H", 80), delay: 5 * time.Minute},
+			{name: "end of the second half", status: status("2H", 88), delay: 2 * time.Minute},
+			{name: "break before the extra time", status: status("BT", 90), delay: 10 * time.Minute},
+			{name: "extra time is polled until its 115th minute", status: status("ET", 108), delay: 7 * time.Minute},
+			{name: "penalties", status: status("P", 120), delay: 2 * time.Minute},
+			{name: "interrupted", status: status("INT", 60), delay: 2 * time.Minute},
+			{name: "postponed", status: status("PST", 0), delay: 15 * time.Minute},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				s := schedule(t, "Regular Season - 21", 24*time.Hour)
+				s.provider.On("Result", mock.Anything, mock.Anything).Return(tt.status, nil).Once()
+				s.provider.On("Result", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("football api: %w", errs.ErrCircuitOpen))
+
+				s.clock.Advance(115*time.Minute + tt.delay - time.Second)
+				s.provider.AssertNumberOfCalls(t, "Result", 1)
+
+				s.clock.Advance(time.Second)
+				s.provider.AssertNumberOfCalls(t, "Result", 2)
+			})
+		}
+	})
+
+	t.Run("it should save the result of the match finished after extra time or penalties", func(t *testing.T) {
+		for _, short := range []string{"FT", "AET", "PEN"} {
+			t.Run(short, func(t *testing.T) {
+				s := schedule(t, "Final", 24*time.Hour)
+				s.provider.On("Result", mock.Anything, mock.Anything).Return(status(short, 120), nil).Once()
+				s.resultPublisher.On("PublishMatchResult", mock.Anything, uint(7)).Return(nil).Once()
+
+				s.clock.Advance(145 * time.Minute)
+
+				select {
+				case result := <-s.saved:
+					assert.Equal(t, repository.Successful, result.ResultStatus)
+					assert.Equal(t, short, result.Data.Fixture.Status.Short)
+				case <-time.After(time.Second):
+					t.Fatal("match result is not saved")
+				}
+			})
+		}
+	})
+
+	t.Run("it should count live attempts after the live polling max duration", func(t *testing.T) {
+		s := schedule(t, "Regular Season - 21", 24*time.Hour)
+		s.provider.On("Result", mock.Anything, mock.Anything).Return(status("INT", 60), nil)
+
+		// attempts from 115' to 179' every 2 minutes are not counted
+		s.clock.Advance(179 * time.Minute)
+		s.provider.AssertNumberOfCalls(t, "Result", 33)
+
+		// attempts at 181' and 183' are counted, so the retries limit is reached
+		s.clock.Advance(4 * time.Minute)
+		s.provider.AssertNumberOfCalls(t, "Result", 35)
+		waitForFailed(t, s.failed)
+	})
+
+	t.Run("it should not count attempts when providers are unavailable within the max polling duration", func(t *testing.T) {
+		s := schedule(t, "Regular Season - 21", 3*time.Hour)
+		s.provider.On("Result", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("football api: %w", errs.ErrCircuitOpen))
+
+		// attempts at 115', 130', 145', 160' and 175' are not counted
+		s.clock.Advance(175 * time.Minute)
+		s.provider.AssertNumberOfCalls(t, "Result", 5)
+
+		// attempts at 190' and 205' are counted, so the retries limit is reached
+		s.clock.Advance(30 * time.Minute)
+		s.provider.AssertNumberOfCalls(t, "Result", 7)
+		waitForFailed(t, s.failed)
+	})
+
+	t.Run("it should count attempts when football-api quota is exhausted after the max polling duration", func(t *testing.T) {
+		s := schedule(t, "Regular Season - 21", time.Hour)
+		s.provider.On("Result", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("football api: %w", errs.ErrQuotaExhausted))
+
+		s.clock.Advance(130 * time.Minute)
+		s.provider.AssertNumberOfCalls(t, "Result", 2)
+		waitForFailed(t, s.failed)
+	})
+}
+
+// matchServiceSetup contains dependencies and polling config of the match service under test.
+// Missing dependencies are replaced by mocks, zero config values - by the defaults of newMatchService.
+type matchServiceSetup struct {
+	aliasRepository              service.AliasRepository
+	matchRepository              service.MatchRepository
+	footballAPIFixtureRepository service.FootballAPIFixtureRepository
+	footballAPIClient            service.FootballAPIClient
+	resultProviders              []service.ResultProvider
+	taskScheduler                service.TaskScheduler
+	resultPublisher              service.ResultPublisher
+	logger                       service.Logger
+	clock                        service.Clock
+	pollingMaxRetries            uint
+	pollingMaxDuration           time.Duration
+	livePollingMaxDuration       time.Duration
+	verification                 service.ResultVerification
+}
+
+// newMatchService creates the match service which makes the first attempt in 115 minutes (145 for knockout rounds)
+// and next attempts every 15 minutes, 5 times by default.
+func newMatchService(t *testing.T, s matchServiceSetup) *service.MatchService {
+	if s.aliasRepository == nil {
+		s.aliasRepository = mocks.NewAliasRepository(t)
+	}
+	if s.matchRepository == nil {
+		s.matchRepository = mocks.NewMatchRepository(t)
+	}
+	if s.footballAPIFixtureRepository == nil {
+		s.footballAPIFixtureRepository = mocks.NewFootballAPIFixtureRepository(t)
+	}
+	if s.footballAPIClient == nil {
+		s.footballAPIClient = mocks.NewFootballAPIClient(t)
+	}
+	if s.taskScheduler == nil {
+		s.taskScheduler = mocks.NewTaskScheduler(t)
+	}
+	if s.resultPublisher == nil {
+		s.resultPublisher = mocks.NewResultPublisher(t)
+	}
+	if s.logger == nil {
+		s.logger = mocks.NewLogger(t)
+	}
+	if s.clock == nil {
+		s.clock = clock.New()
+	}
+	if s.pollingMaxRetries == 0 {
+		s.pollingMaxRetries = 5
+	}
+	if s.pollingMaxDuration == 0 {
+		s.pollingMaxDuration = 24 * time.Hour
+	}
+	if s.livePollingMaxDuration == 0 {
+		s.livePollingMaxDuration = 4 * time.Hour
+	}
+	if s.verification.Mode == "" {
+		s.verification.Mode = service.ResultVerificationOff
+	}
+
+	return service.NewMatchService(
+		s.aliasRepository,
+		s.matchRepository,
+		s.footballAPIFixtureRepository,
+		s.footballAPIClient,
+		s.resultProviders,
+		s.taskScheduler,
+		s.resultPublisher,
+		s.logger,
+		s.clock,
+		metrics.New(),
+		s.pollingMaxRetries,
+		15*time.Minute,
+		115*time.Minute,
+		s.pollingMaxDuration,
+		service.LivePolling{Interval: 2 * time.Minute, HalfTimeInterval: 10 * time.Minute, KnockoutFirstAttemptDelay: 145 * time.Minute, MaxDuration: s.livePollingMaxDuration},
+		s.verification,
+	)
+}
+
 func fakeRepositoryMatch(teams bool, fixtures bool) repository.Match {
 	matchID := uint(gofakeit.Uint8())
 	homeTeamID := uint(gofakeit.Uint8())
@@ -177,6 +731,10 @@ func expectedMatch(repositoryMatch repository.Match) service.Match {
 				ID:   repositoryMatch.FootballApiFixtures[i].ID,
 				Home: 4,
 				Away: 2,
+				Teams: service.TeamsExternal{
+					Home: service.TeamExternal{ID: 33, Name: "Manchester United"},
+					Away: service.TeamExternal{ID: 35, Name: "Bournemouth"},
+				},
 			})
 		}
 	}
@@ -1,4 +1,4 @@
-// Code generated by mockery v2.42.1. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package mocks
 
@@ -14,6 +14,36 @@ type AliasRepository struct {
 	mock.Mock
 }
 
+// Create provides a mock function with given fields: ctx, teamID, alias
+func (_m *AliasRepository) Create(ctx context.Context, teamID uint, alias string) (*repository.Alias, error) {
+	ret := _m.Called(ctx, teamID, alias)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *repository.Alias
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) (*repository.Alias, error)); ok {
+		return rf(ctx, teamID, alias)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) *repository.Alias); ok {
+		r0 = rf(ctx, teamID, alias)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Alias)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, teamID, alias)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Find provides a mock function with given fields: ctx, alias
 func (_m *AliasRepository) Find(ctx context.Context, alias string) (*repository.Alias, error) {
 	ret := _m.Called(ctx, alias)
@@ -44,6 +74,96 @@ func (_m *AliasRepository) Find(ctx context.Context, alias string) (*repository.
 	return r0, r1
 }
 
+// FindByFootballAPITeamID provides a mock function with given fields: ctx, footballAPITeamID
+func (_m *AliasRepository) FindByFootballAPITeamID(ctx context.Context, footballAPITeamID uint) (*repository.Alias, error) {
+	ret := _m.Called(ctx, footballAPITeamID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByFootballAPITeamID")
+	}
+
+	var r0 *repository.Alias
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*repository.Alias, error)); ok {
+		return rf(ctx, footballAPITeamID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *repository.Alias); ok {
+		r0 = rf(ctx, footballAPITeamID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Alias)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, footballAPITeamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportInTrx provides a mock function with given fields: ctx, teams
+func (_m *AliasRepository) ImportInTrx(ctx context.Context, teams []repository.TeamImport) (*repository.ImportResult, error) {
+	ret := _m.Called(ctx, teams)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportInTrx")
+	}
+
+	var r0 *repository.ImportResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []repository.TeamImport) (*repository.ImportResult, error)); ok {
+		return rf(ctx, teams)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []repository.TeamImport) *repository.ImportResult); ok {
+		r0 = rf(ctx, teams)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.ImportResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []repository.TeamImport) error); ok {
+		r1 = rf(ctx, teams)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTeams provides a mock function with given fields: ctx
+func (_m *AliasRepository) ListTeams(ctx context.Context) ([]repository.Team, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTeams")
+	}
+
+	var r0 []repository.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]repository.Team, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []repository.Team); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SaveInTrx provides a mock function with given fields: ctx, alias, footballAPITeamID
 func (_m *AliasRepository) SaveInTrx(ctx context.Context, alias string, footballAPITeamID uint) error {
 	ret := _m.Called(ctx, alias, footballAPITeamID)
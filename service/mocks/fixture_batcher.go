@@ -0,0 +1,60 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	client "github.com/andrewshostak/result-service/client"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FixtureBatcher is an autogenerated mock type for the FixtureBatcher type
+type FixtureBatcher struct {
+	mock.Mock
+}
+
+// Fixture provides a mock function with given fields: ctx, id
+func (_m *FixtureBatcher) Fixture(ctx context.Context, id uint) (*client.Result, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Fixture")
+	}
+
+	var r0 *client.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*client.Result, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *client.Result); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Result)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewFixtureBatcher creates a new instance of FixtureBatcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFixtureBatcher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FixtureBatcher {
+	mock := &FixtureBatcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
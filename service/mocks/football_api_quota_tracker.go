@@ -0,0 +1,47 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	client "github.com/andrewshostak/result-service/client"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FootballAPIQuotaTracker is an autogenerated mock type for the FootballAPIQuotaTracker type
+type FootballAPIQuotaTracker struct {
+	mock.Mock
+}
+
+// Quota provides a mock function with no fields
+func (_m *FootballAPIQuotaTracker) Quota() *client.Quota {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Quota")
+	}
+
+	var r0 *client.Quota
+	if rf, ok := ret.Get(0).(func() *client.Quota); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Quota)
+		}
+	}
+
+	return r0
+}
+
+// NewFootballAPIQuotaTracker creates a new instance of FootballAPIQuotaTracker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFootballAPIQuotaTracker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FootballAPIQuotaTracker {
+	mock := &FootballAPIQuotaTracker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,46 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FootballAPIStatusClient is an autogenerated mock type for the FootballAPIStatusClient type
+type FootballAPIStatusClient struct {
+	mock.Mock
+}
+
+// Status provides a mock function with given fields: ctx
+func (_m *FootballAPIStatusClient) Status(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Status")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewFootballAPIStatusClient creates a new instance of FootballAPIStatusClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFootballAPIStatusClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FootballAPIStatusClient {
+	mock := &FootballAPIStatusClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
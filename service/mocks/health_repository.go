@@ -0,0 +1,77 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	repository "github.com/andrewshostak/result-service/repository"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HealthRepository is an autogenerated mock type for the HealthRepository type
+type HealthRepository struct {
+	mock.Mock
+}
+
+// MigrationVersion provides a mock function with given fields: ctx
+func (_m *HealthRepository) MigrationVersion(ctx context.Context) (*repository.MigrationVersion, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MigrationVersion")
+	}
+
+	var r0 *repository.MigrationVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*repository.MigrationVersion, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *repository.MigrationVersion); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.MigrationVersion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Ping provides a mock function with given fields: ctx
+func (_m *HealthRepository) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewHealthRepository creates a new instance of HealthRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHealthRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HealthRepository {
+	mock := &HealthRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,4 +1,4 @@
-// Code generated by mockery v2.42.1. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package mocks
 
@@ -13,7 +13,27 @@ type Logger struct {
 	mock.Mock
 }
 
-// Error provides a mock function with given fields:
+// Debug provides a mock function with no fields
+func (_m *Logger) Debug() *zerolog.Event {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Debug")
+	}
+
+	var r0 *zerolog.Event
+	if rf, ok := ret.Get(0).(func() *zerolog.Event); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*zerolog.Event)
+		}
+	}
+
+	return r0
+}
+
+// Error provides a mock function with no fields
 func (_m *Logger) Error() *zerolog.Event {
 	ret := _m.Called()
 
@@ -33,7 +53,7 @@ func (_m *Logger) Error() *zerolog.Event {
 	return r0
 }
 
-// Info provides a mock function with given fields:
+// Info provides a mock function with no fields
 func (_m *Logger) Info() *zerolog.Event {
 	ret := _m.Called()
 
@@ -53,6 +73,26 @@ func (_m *Logger) Info() *zerolog.Event {
 	return r0
 }
 
+// Warn provides a mock function with no fields
+func (_m *Logger) Warn() *zerolog.Event {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Warn")
+	}
+
+	var r0 *zerolog.Event
+	if rf, ok := ret.Get(0).(func() *zerolog.Event); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*zerolog.Event)
+		}
+	}
+
+	return r0
+}
+
 // NewLogger creates a new instance of Logger. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewLogger(t interface {
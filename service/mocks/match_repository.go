@@ -1,4 +1,4 @@
-// Code generated by mockery v2.42.1. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package mocks
 
@@ -122,6 +122,24 @@ func (_m *MatchRepository) One(ctx context.Context, search repository.Match) (*r
 	return r0, r1
 }
 
+// SaveResultInTrx provides a mock function with given fields: ctx, matchResult
+func (_m *MatchRepository) SaveResultInTrx(ctx context.Context, matchResult repository.MatchResult) error {
+	ret := _m.Called(ctx, matchResult)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveResultInTrx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.MatchResult) error); ok {
+		r0 = rf(ctx, matchResult)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, id, resultStatus
 func (_m *MatchRepository) Update(ctx context.Context, id uint, resultStatus repository.ResultStatus) (*repository.Match, error) {
 	ret := _m.Called(ctx, id, resultStatus)
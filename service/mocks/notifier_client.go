@@ -0,0 +1,48 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	client "github.com/andrewshostak/result-service/client"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NotifierClient is an autogenerated mock type for the NotifierClient type
+type NotifierClient struct {
+	mock.Mock
+}
+
+// Notify provides a mock function with given fields: ctx, notification
+func (_m *NotifierClient) Notify(ctx context.Context, notification client.Notification) error {
+	ret := _m.Called(ctx, notification)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Notify")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.Notification) error); ok {
+		r0 = rf(ctx, notification)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewNotifierClient creates a new instance of NotifierClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotifierClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotifierClient {
+	mock := &NotifierClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
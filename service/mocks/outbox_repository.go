@@ -0,0 +1,109 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	repository "github.com/andrewshostak/result-service/repository"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// OutboxRepository is an autogenerated mock type for the OutboxRepository type
+type OutboxRepository struct {
+	mock.Mock
+}
+
+// Claim provides a mock function with given fields: ctx, now, lockedUntil, limit
+func (_m *OutboxRepository) Claim(ctx context.Context, now time.Time, lockedUntil time.Time, limit int) ([]repository.OutboxNotification, error) {
+	ret := _m.Called(ctx, now, lockedUntil, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Claim")
+	}
+
+	var r0 []repository.OutboxNotification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) ([]repository.OutboxNotification, error)); ok {
+		return rf(ctx, now, lockedUntil, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) []repository.OutboxNotification); ok {
+		r0 = rf(ctx, now, lockedUntil, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.OutboxNotification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, int) error); ok {
+		r1 = rf(ctx, now, lockedUntil, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClaimByMatch provides a mock function with given fields: ctx, matchID, now, lockedUntil, limit
+func (_m *OutboxRepository) ClaimByMatch(ctx context.Context, matchID uint, now time.Time, lockedUntil time.Time, limit int) ([]repository.OutboxNotification, error) {
+	ret := _m.Called(ctx, matchID, now, lockedUntil, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimByMatch")
+	}
+
+	var r0 []repository.OutboxNotification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, time.Time, time.Time, int) ([]repository.OutboxNotification, error)); ok {
+		return rf(ctx, matchID, now, lockedUntil, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, time.Time, time.Time, int) []repository.OutboxNotification); ok {
+		r0 = rf(ctx, matchID, now, lockedUntil, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.OutboxNotification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, time.Time, time.Time, int) error); ok {
+		r1 = rf(ctx, matchID, now, lockedUntil, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompleteInTrx provides a mock function with given fields: ctx, notification, subscription
+func (_m *OutboxRepository) CompleteInTrx(ctx context.Context, notification repository.OutboxNotification, subscription repository.Subscription) error {
+	ret := _m.Called(ctx, notification, subscription)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteInTrx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.OutboxNotification, repository.Subscription) error); ok {
+		r0 = rf(ctx, notification, subscription)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewOutboxRepository creates a new instance of OutboxRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOutboxRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OutboxRepository {
+	mock := &OutboxRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
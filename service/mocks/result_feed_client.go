@@ -0,0 +1,60 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	client "github.com/andrewshostak/result-service/client"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ResultFeedClient is an autogenerated mock type for the ResultFeedClient type
+type ResultFeedClient struct {
+	mock.Mock
+}
+
+// SearchMatches provides a mock function with given fields: ctx, date
+func (_m *ResultFeedClient) SearchMatches(ctx context.Context, date string) (*client.ResultFeedResponse, error) {
+	ret := _m.Called(ctx, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchMatches")
+	}
+
+	var r0 *client.ResultFeedResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*client.ResultFeedResponse, error)); ok {
+		return rf(ctx, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *client.ResultFeedResponse); ok {
+		r0 = rf(ctx, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.ResultFeedResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewResultFeedClient creates a new instance of ResultFeedClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewResultFeedClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ResultFeedClient {
+	mock := &ResultFeedClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
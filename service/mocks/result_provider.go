@@ -0,0 +1,77 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	service "github.com/andrewshostak/result-service/service"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ResultProvider is an autogenerated mock type for the ResultProvider type
+type ResultProvider struct {
+	mock.Mock
+}
+
+// ID provides a mock function with no fields
+func (_m *ResultProvider) ID() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ID")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Result provides a mock function with given fields: ctx, query
+func (_m *ResultProvider) Result(ctx context.Context, query service.ResultQuery) (*service.Data, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Result")
+	}
+
+	var r0 *service.Data
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.ResultQuery) (*service.Data, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, service.ResultQuery) *service.Data); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.Data)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, service.ResultQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewResultProvider creates a new instance of ResultProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewResultProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ResultProvider {
+	mock := &ResultProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,46 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ResultPublisher is an autogenerated mock type for the ResultPublisher type
+type ResultPublisher struct {
+	mock.Mock
+}
+
+// PublishMatchResult provides a mock function with given fields: ctx, matchID
+func (_m *ResultPublisher) PublishMatchResult(ctx context.Context, matchID uint) error {
+	ret := _m.Called(ctx, matchID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishMatchResult")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, matchID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewResultPublisher creates a new instance of ResultPublisher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewResultPublisher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ResultPublisher {
+	mock := &ResultPublisher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
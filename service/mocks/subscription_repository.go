@@ -0,0 +1,137 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	repository "github.com/andrewshostak/result-service/repository"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SubscriptionRepository is an autogenerated mock type for the SubscriptionRepository type
+type SubscriptionRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, subscription
+func (_m *SubscriptionRepository) Create(ctx context.Context, subscription repository.Subscription) (*repository.Subscription, error) {
+	ret := _m.Called(ctx, subscription)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *repository.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Subscription) (*repository.Subscription, error)); ok {
+		return rf(ctx, subscription)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Subscription) *repository.Subscription); ok {
+		r0 = rf(ctx, subscription)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.Subscription) error); ok {
+		r1 = rf(ctx, subscription)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *SubscriptionRepository) Delete(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// List provides a mock function with given fields: ctx, matchID
+func (_m *SubscriptionRepository) List(ctx context.Context, matchID uint) ([]repository.Subscription, error) {
+	ret := _m.Called(ctx, matchID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []repository.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]repository.Subscription, error)); ok {
+		return rf(ctx, matchID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []repository.Subscription); ok {
+		r0 = rf(ctx, matchID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, matchID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// One provides a mock function with given fields: ctx, matchID, key, baseURL
+func (_m *SubscriptionRepository) One(ctx context.Context, matchID uint, key string, baseURL string) (*repository.Subscription, error) {
+	ret := _m.Called(ctx, matchID, key, baseURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for One")
+	}
+
+	var r0 *repository.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, string) (*repository.Subscription, error)); ok {
+		return rf(ctx, matchID, key, baseURL)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, string) *repository.Subscription); ok {
+		r0 = rf(ctx, matchID, key, baseURL)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string, string) error); ok {
+		r1 = rf(ctx, matchID, key, baseURL)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSubscriptionRepository creates a new instance of SubscriptionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSubscriptionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SubscriptionRepository {
+	mock := &SubscriptionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
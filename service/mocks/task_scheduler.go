@@ -1,4 +1,4 @@
-// Code generated by mockery v2.42.1. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package mocks
 
@@ -20,17 +20,17 @@ func (_m *TaskScheduler) Cancel(key string) {
 	_m.Called(key)
 }
 
-// Schedule provides a mock function with given fields: key, task, period, startTime
-func (_m *TaskScheduler) Schedule(key string, task func(context.Context), period time.Duration, startTime time.Time) error {
-	ret := _m.Called(key, task, period, startTime)
+// ScheduleOnce provides a mock function with given fields: key, task, startTime
+func (_m *TaskScheduler) ScheduleOnce(key string, task func(context.Context), startTime time.Time) error {
+	ret := _m.Called(key, task, startTime)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Schedule")
+		panic("no return value specified for ScheduleOnce")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, func(context.Context), time.Duration, time.Time) error); ok {
-		r0 = rf(key, task, period, startTime)
+	if rf, ok := ret.Get(0).(func(string, func(context.Context), time.Time) error); ok {
+		r0 = rf(key, task, startTime)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -31,6 +31,9 @@ type DeleteSubscriptionRequest struct {
 type Match struct {
 	ID       uint
 	StartsAt time.Time
+	// TraceID and SpanID are of the request which created the match
+	TraceID string
+	SpanID  string
 
 	FootballApiFixtures []FootballAPIFixture
 	HomeTeam            *Team
@@ -50,15 +53,77 @@ type Alias struct {
 	FootballApiTeam *FootballApiTeam
 }
 
+type TeamAliases struct {
+	TeamID            uint
+	FootballAPITeamID *uint
+	Aliases           []string
+}
+
+type AliasImportResult struct {
+	CreatedTeams            int
+	CreatedAliases          int
+	CreatedFootballAPITeams int
+	Conflicts               []AliasImportConflict
+}
+
+type AliasImportConflict struct {
+	TeamID uint
+	Alias  string
+	Reason string
+}
+
 type FootballApiTeam struct {
 	ID     uint
 	TeamID uint
 }
 
 type FootballAPIFixture struct {
-	ID   uint
-	Home uint
-	Away uint
+	ID    uint
+	Home  uint
+	Away  uint
+	Round string
+	Teams TeamsExternal
+}
+
+const (
+	ResultVerificationOff      = "off"
+	ResultVerificationProvider = "provider"
+	ResultVerificationDelayed  = "delayed"
+)
+
+// LivePolling configures polling of a match in progress.
+// Interval is used once the match passes 85' of the second half or extra time, HalfTimeInterval - during the breaks.
+// KnockoutFirstAttemptDelay replaces the first attempt delay for knockout rounds, which may have extra time and penalties.
+// Attempts made while the match is in progress are not counted only within MaxDuration from the match start.
+type LivePolling struct {
+	Interval                  time.Duration
+	HalfTimeInterval          time.Duration
+	KnockoutFirstAttemptDelay time.Duration
+	MaxDuration               time.Duration
+}
+
+// ResultVerification configures how a finished match result is confirmed before it is saved as successful.
+// Provider mode compares the result with another provider, delayed mode reads the result again after Delay.
+type ResultVerification struct {
+	Mode  string
+	Delay time.Duration
+}
+
+// NotifierConcurrency limits parallel deliveries of a notifier run. PerHost keeps a slow subscriber
+// from taking all workers, so subscribers of other hosts are notified in time.
+// Workers and DeliveryTimeout also define the size of a claimed batch of notifications and its lease.
+type NotifierConcurrency struct {
+	Workers         uint
+	PerHost         uint
+	DeliveryTimeout time.Duration
+}
+
+// ResultQuery describes a fixture which result is requested from ResultProvider.
+// Fixture contains the last known data, aliases help providers which identify matches by team names.
+type ResultQuery struct {
+	Fixture     Data
+	HomeAliases []string
+	AwayAliases []string
 }
 
 type Subscription struct {
@@ -75,6 +140,7 @@ type Subscription struct {
 
 type Data struct {
 	Fixture Fixture       `json:"fixture"`
+	League  FixtureLeague `json:"league"`
 	Teams   TeamsExternal `json:"teams"`
 	Goals   Goals         `json:"goals"`
 }
@@ -90,6 +156,12 @@ type Fixture struct {
 	Date   string `json:"date"`
 }
 
+type FixtureLeague struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Round string `json:"round"`
+}
+
 type TeamsExternal struct {
 	Home TeamExternal `json:"home"`
 	Away TeamExternal `json:"away"`
@@ -106,8 +178,9 @@ type Goals struct {
 }
 
 type Status struct {
-	Short string `json:"short"`
-	Long  string `json:"long"`
+	Short   string `json:"short"`
+	Long    string `json:"long"`
+	Elapsed *uint  `json:"elapsed"`
 }
 
 type League struct {
@@ -115,6 +188,45 @@ type League struct {
 	Name string
 }
 
+type BackfillRun struct {
+	Season     uint
+	Trigger    string
+	StartedAt  time.Time
+	FinishedAt *time.Time
+	Report     *BackfillReport
+	Err        error
+}
+
+type BackfillReport struct {
+	Season  uint
+	DryRun  bool
+	Leagues []LeagueBackfillReport
+}
+
+type LeagueBackfillReport struct {
+	League    LeagueData
+	Created   []TeamExternal
+	Renamed   []AliasRename
+	Existing  []TeamExternal
+	Conflicts []AliasConflict
+	Failed    []TeamExternal
+	Err       error
+}
+
+type AliasRename struct {
+	Team           TeamExternal
+	ExistingAlias  string
+	ExistingTeamID uint
+}
+
+type AliasConflict struct {
+	Team                      TeamExternal
+	ExistingAlias             string
+	ExistingTeamID            uint
+	ExistingFootballAPITeamID uint
+	Reason                    string
+}
+
 type Country struct {
 	Name string
 }
@@ -127,9 +239,11 @@ func fromRepositoryFootballAPIFixture(f repository.FootballApiFixture) (*Footbal
 	}
 
 	return &FootballAPIFixture{
-		ID:   f.ID,
-		Home: d.Goals.Home,
-		Away: d.Goals.Away,
+		ID:    f.ID,
+		Home:  d.Goals.Home,
+		Away:  d.Goals.Away,
+		Round: d.League.Round,
+		Teams: d.Teams,
 	}, nil
 }
 
@@ -138,11 +252,17 @@ func fromClientFootballAPIFixture(c client.Result) Data {
 		Fixture: Fixture{
 			ID: c.Fixture.ID,
 			Status: Status{
-				Short: c.Fixture.Status.Short,
-				Long:  c.Fixture.Status.Long,
+				Short:   c.Fixture.Status.Short,
+				Long:    c.Fixture.Status.Long,
+				Elapsed: c.Fixture.Status.Elapsed,
 			},
 			Date: c.Fixture.Date,
 		},
+		League: FixtureLeague{
+			ID:    c.League.ID,
+			Name:  c.League.Name,
+			Round: c.League.Round,
+		},
 		Teams: TeamsExternal{
 			Home: TeamExternal{
 				ID:   c.Teams.Home.ID,
@@ -218,6 +338,8 @@ func fromRepositoryMatch(m repository.Match) (*Match, error) {
 	return &Match{
 		ID:                  m.ID,
 		StartsAt:            m.StartsAt,
+		TraceID:             valueOrEmpty(m.TraceID),
+		SpanID:              valueOrEmpty(m.SpanID),
 		FootballApiFixtures: fixtures,
 		HomeTeam:            homeTeam,
 		AwayTeam:            awayTeam,
@@ -259,6 +381,51 @@ func fromRepositoryAlias(a repository.Alias) Alias {
 	}
 }
 
+func fromRepositoryTeamAliases(t repository.Team) TeamAliases {
+	aliases := make([]string, 0, len(t.Aliases))
+	for i := range t.Aliases {
+		aliases = append(aliases, t.Aliases[i].Alias)
+	}
+
+	var footballAPITeamID *uint
+	if t.FootballApiTeam != nil {
+		id := t.FootballApiTeam.ID
+		footballAPITeamID = &id
+	}
+
+	return TeamAliases{
+		TeamID:            t.ID,
+		FootballAPITeamID: footballAPITeamID,
+		Aliases:           aliases,
+	}
+}
+
+func fromRepositoryImportResult(r repository.ImportResult) AliasImportResult {
+	conflicts := make([]AliasImportConflict, 0, len(r.Conflicts))
+	for i := range r.Conflicts {
+		conflicts = append(conflicts, AliasImportConflict{
+			TeamID: r.Conflicts[i].SourceTeamID,
+			Alias:  r.Conflicts[i].Alias,
+			Reason: r.Conflicts[i].Reason,
+		})
+	}
+
+	return AliasImportResult{
+		CreatedTeams:            r.CreatedTeams,
+		CreatedAliases:          r.CreatedAliases,
+		CreatedFootballAPITeams: r.CreatedFootballAPITeams,
+		Conflicts:               conflicts,
+	}
+}
+
+func toRepositoryTeamImport(t TeamAliases) repository.TeamImport {
+	return repository.TeamImport{
+		SourceTeamID:      t.TeamID,
+		FootballAPITeamID: t.FootballAPITeamID,
+		Aliases:           t.Aliases,
+	}
+}
+
 func fromRepositorySubscription(s repository.Subscription) (*Subscription, error) {
 	var match *Match
 
@@ -282,29 +449,22 @@ func fromRepositorySubscription(s repository.Subscription) (*Subscription, error
 	}, nil
 }
 
-func fromRepositorySubscriptions(s []repository.Subscription) ([]Subscription, error) {
-	subscriptions := make([]Subscription, 0, len(s))
-	for i := range s {
-		repositorySubscription, err := fromRepositorySubscription(s[i])
-		if err != nil {
-			return nil, err
-		}
-		subscriptions = append(subscriptions, *repositorySubscription)
-	}
-
-	return subscriptions, nil
-}
-
 func toRepositoryFootballAPIFixtureData(data Data) repository.Data {
 	return repository.Data{
 		Fixture: repository.Fixture{
 			ID: data.Fixture.ID,
 			Status: repository.Status{
-				Short: data.Fixture.Status.Short,
-				Long:  data.Fixture.Status.Long,
+				Short:   data.Fixture.Status.Short,
+				Long:    data.Fixture.Status.Long,
+				Elapsed: data.Fixture.Status.Elapsed,
 			},
 			Date: data.Fixture.Date,
 		},
+		League: repository.FixtureLeague{
+			ID:    data.League.ID,
+			Name:  data.League.Name,
+			Round: data.League.Round,
+		},
 		Teams: repository.TeamsExternal{
 			Home: repository.TeamExternal{
 				ID:   data.Teams.Home.ID,
@@ -321,3 +481,59 @@ func toRepositoryFootballAPIFixtureData(data Data) repository.Data {
 		},
 	}
 }
+
+// FootballAPIQuota is the last known football-api quota. Nil values mean the quota header was not received.
+type FootballAPIQuota struct {
+	DailyLimit      *int
+	DailyRemaining  *int
+	MinuteLimit     *int
+	MinuteRemaining *int
+	UpdatedAt       time.Time
+}
+
+func fromClientQuota(q client.Quota) FootballAPIQuota {
+	return FootballAPIQuota{
+		DailyLimit:      knownQuotaValue(q.DailyLimit),
+		DailyRemaining:  knownQuotaValue(q.DailyRemaining),
+		MinuteLimit:     knownQuotaValue(q.MinuteLimit),
+		MinuteRemaining: knownQuotaValue(q.MinuteRemaining),
+		UpdatedAt:       q.UpdatedAt,
+	}
+}
+
+func knownQuotaValue(value int) *int {
+	if value < 0 {
+		return nil
+	}
+
+	return &value
+}
+
+const (
+	HealthStatusOK          = "ok"
+	HealthStatusError       = "error"
+	HealthStatusDegraded    = "degraded"
+	HealthStatusUnavailable = "unavailable"
+)
+
+// HealthReport is ok when all checks pass, degraded when only non-critical checks fail, and unavailable otherwise.
+type HealthReport struct {
+	Status string
+	Checks []HealthCheck
+}
+
+type HealthCheck struct {
+	Name     string
+	Status   string
+	Critical bool
+	Err      error
+	Details  map[string]any
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
@@ -4,79 +4,300 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"sync"
 	"time"
 
 	"github.com/andrewshostak/result-service/client"
+	"github.com/andrewshostak/result-service/errs"
 	"github.com/andrewshostak/result-service/repository"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// deliveriesPerWorker is the number of deliveries each worker makes in one claimed batch of notifications.
+const deliveriesPerWorker = 10
+
 type NotifierService struct {
-	subscriptionRepository SubscriptionRepository
-	notifierClient         NotifierClient
-	logger                 Logger
+	outboxRepository OutboxRepository
+	notifierClient   NotifierClient
+	logger           Logger
+	clock            Clock
+	metrics          Metrics
+	concurrency      NotifierConcurrency
 }
 
-func NewNotifierService(subscriptionRepository SubscriptionRepository, notifierClient NotifierClient, logger Logger) *NotifierService {
-	return &NotifierService{subscriptionRepository: subscriptionRepository, notifierClient: notifierClient, logger: logger}
+func NewNotifierService(
+	outboxRepository OutboxRepository,
+	notifierClient NotifierClient,
+	logger Logger,
+	clock Clock,
+	metrics Metrics,
+	concurrency NotifierConcurrency,
+) *NotifierService {
+	return &NotifierService{
+		outboxRepository: outboxRepository,
+		notifierClient:   notifierClient,
+		logger:           logger,
+		clock:            clock,
+		metrics:          metrics,
+		concurrency:      concurrency,
+	}
 }
 
+// NotifySubscribers notifies subscribers from the notification outbox. Each subscription is handled on its own: a subscription
+// which can't be notified is marked as failed without affecting the rest. An error is returned when notifications
+// can't be claimed or statuses of some of them can't be saved. Such notifications are delivered again after the lease.
+// Subscriptions are notified in parallel by a limited number of workers with a limit per subscriber host.
 func (s *NotifierService) NotifySubscribers(ctx context.Context) error {
-	subscriptions, err := s.subscriptionRepository.ListUnNotified(ctx)
-	if err != nil {
-		return err
+	ctx, span := tracer.Start(ctx, "notify subscribers")
+	defer span.End()
+
+	start := s.clock.Now()
+	defer func() {
+		s.metrics.ObserveNotifierRun(s.clock.Now().Sub(start))
+	}()
+
+	return s.notifyInBatches(ctx, func(now time.Time, lockedUntil time.Time, limit int) ([]repository.OutboxNotification, error) {
+		notifications, err := s.outboxRepository.Claim(ctx, now, lockedUntil, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to claim notifications: %w", err)
+		}
+
+		return notifications, nil
+	})
+}
+
+// NotifyMatchSubscribers notifies subscribers of the match right after its result is saved.
+func (s *NotifierService) NotifyMatchSubscribers(ctx context.Context, matchID uint) error {
+	ctx, span := tracer.Start(ctx, "notify match subscribers", trace.WithAttributes(attribute.Int64("match.id", int64(matchID))))
+	defer span.End()
+
+	return s.notifyInBatches(ctx, func(now time.Time, lockedUntil time.Time, limit int) ([]repository.OutboxNotification, error) {
+		notifications, err := s.outboxRepository.ClaimByMatch(ctx, matchID, now, lockedUntil, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to claim notifications of match %d: %w", matchID, err)
+		}
+
+		return notifications, nil
+	})
+}
+
+// notifyInBatches claims notifications and notifies their subscribers until a claimed batch is not full.
+// Errors of all batches are returned together.
+func (s *NotifierService) notifyInBatches(
+	ctx context.Context,
+	claim func(now time.Time, lockedUntil time.Time, limit int) ([]repository.OutboxNotification, error),
+) error {
+	limit := int(max(s.concurrency.Workers, 1)) * deliveriesPerWorker
+
+	var notifyErrors []error
+	for ctx.Err() == nil {
+		now := s.clock.Now()
+		notifications, err := claim(now, now.Add(s.lease()), limit)
+		if err != nil {
+			return errors.Join(append(notifyErrors, err)...)
+		}
+
+		if err := s.notify(ctx, notifications); err != nil {
+			notifyErrors = append(notifyErrors, err)
+		}
+
+		if len(notifications) < limit {
+			break
+		}
+	}
+
+	return errors.Join(notifyErrors...)
+}
+
+// lease is the time a claimed batch is hidden from other notifiers. Each worker makes up to deliveriesPerWorker deliveries
+// limited by the delivery timeout. The lease is twice as long, since deliveries also wait for the limit per host.
+// Deliveries which can't be finished within the lease are left to the next run.
+func (s *NotifierService) lease() time.Duration {
+	return 2 * deliveriesPerWorker * s.concurrency.DeliveryTimeout
+}
+
+func (s *NotifierService) notify(ctx context.Context, notifications []repository.OutboxNotification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	s.logger.Info().Msg(fmt.Sprintf("found %d subscription(s) to notify", len(notifications)))
+
+	var (
+		wg           sync.WaitGroup
+		mutex        sync.Mutex
+		updateErrors []error
+	)
+
+	workers := make(chan struct{}, max(s.concurrency.Workers, 1))
+	hosts := newHostLimiter(max(s.concurrency.PerHost, 1))
+
+	for i := range notifications {
+		wg.Add(1)
+		go func(notification repository.OutboxNotification) {
+			defer wg.Done()
+
+			subscription := repository.Subscription{ID: notification.SubscriptionID, MatchID: notification.MatchID}
+			if notification.Subscription != nil {
+				subscription = *notification.Subscription
+			}
+
+			// the host slot is taken first, so deliveries waiting for a slow host don't hold workers
+			host := subscriptionHost(subscription.Url)
+			hosts.acquire(host)
+			defer hosts.release(host)
+
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			if notification.LockedUntil != nil && s.clock.Now().Add(s.concurrency.DeliveryTimeout).After(*notification.LockedUntil) {
+				s.logger.Warn().
+					Uint("subscription_id", subscription.ID).
+					Uint("match_id", subscription.MatchID).
+					Msg("notification lease expires before the delivery. it is delivered by the next run")
+				return
+			}
+
+			if err := s.notifySubscriber(ctx, notification, subscription); err != nil {
+				mutex.Lock()
+				updateErrors = append(updateErrors, err)
+				mutex.Unlock()
+			}
+		}(notifications[i])
 	}
 
-	mapped, err := fromRepositorySubscriptions(subscriptions)
+	wg.Wait()
+
+	return errors.Join(updateErrors...)
+}
+
+// notifySubscriber sends the notification and saves the subscription status removing the notification from the outbox.
+// Only a failure to save the status is returned.
+func (s *NotifierService) notifySubscriber(ctx context.Context, notification repository.OutboxNotification, subscription repository.Subscription) error {
+	toUpdate := repository.Subscription{Status: repository.SuccessfulSub}
+
+	mapped, err := toNotifiedSubscription(subscription)
 	if err != nil {
-		return fmt.Errorf("failed to map repository subscriptions: %w", err)
+		s.logger.Error().Err(err).
+			Uint("subscription_id", subscription.ID).
+			Uint("match_id", subscription.MatchID).
+			Msg("subscription can't be notified")
+		toUpdate.Status = repository.ErrorSub
+	} else {
+		toUpdate.Status = s.send(ctx, *mapped)
+	}
+
+	s.metrics.Notification(string(toUpdate.Status))
+
+	if toUpdate.Status == repository.SuccessfulSub {
+		now := s.clock.Now()
+		toUpdate.NotifiedAt = &now
 	}
 
-	if len(mapped) == 0 {
+	err = s.outboxRepository.CompleteInTrx(ctx, notification, toUpdate)
+	if errors.Is(err, errs.ErrNotificationLeaseExpired) {
+		s.logger.Warn().Err(err).
+			Uint("subscription_id", subscription.ID).
+			Uint("match_id", subscription.MatchID).
+			Msg("subscription is notified after the lease. its status is saved by the notifier which claimed it again")
 		return nil
 	}
 
-	if mapped[0].Match == nil {
-		return errors.New(fmt.Sprintf("match of the subscription %d is not found", mapped[0].ID))
+	if err != nil {
+		return fmt.Errorf("failed to update status of subscription %d to %s: %w", subscription.ID, toUpdate.Status, err)
 	}
 
-	if len(mapped[0].Match.FootballApiFixtures) == 0 {
-		return errors.New(fmt.Sprintf("football api fixtures of the match with id %d is not found", mapped[0].MatchID))
+	return nil
+}
+
+func (s *NotifierService) send(ctx context.Context, subscription Subscription) repository.SubscriptionStatus {
+	ctx, span := tracer.Start(ctx, "notify subscriber", withCreatorLink(creatorSpanContext(*subscription.Match)),
+		trace.WithAttributes(attribute.Int64("match.id", int64(subscription.MatchID)), attribute.Int64("subscription.id", int64(subscription.ID))))
+	defer span.End()
+
+	notification := client.Notification{
+		Url:  subscription.Url,
+		Key:  subscription.Key,
+		Home: subscription.Match.FootballApiFixtures[0].Home,
+		Away: subscription.Match.FootballApiFixtures[0].Away,
 	}
 
-	s.logger.Info().Msg(fmt.Sprintf("found %d subscription(s) to notify", len(mapped)))
+	if err := s.notifierClient.Notify(ctx, notification); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to notify subscriber")
+		s.logger.Error().Err(err).
+			Str("url", subscription.Url).
+			Uint("match_id", subscription.MatchID).
+			Msg("failed to notify subscriber")
 
-	for i := range subscriptions {
-		notification := client.Notification{
-			Url:  mapped[i].Url,
-			Key:  mapped[i].Key,
-			Home: mapped[i].Match.FootballApiFixtures[0].Home,
-			Away: mapped[i].Match.FootballApiFixtures[0].Away,
-		}
+		return repository.ErrorSub
+	}
 
-		toUpdate := repository.Subscription{Status: repository.SuccessfulSub}
-		err := s.notifierClient.Notify(ctx, notification)
-		if err != nil {
-			s.logger.Error().Err(err).
-				Str("url", subscriptions[i].Url).
-				Uint("match_id", subscriptions[i].MatchID).
-				Msg("failed to notify subscriber")
-			toUpdate.Status = repository.ErrorSub
-		}
+	s.logger.Info().
+		Str("url", subscription.Url).
+		Uint("match_id", subscription.MatchID).
+		Msg("subscriber successfully notified")
 
-		if toUpdate.Status == repository.SuccessfulSub {
-			now := time.Now()
-			toUpdate.NotifiedAt = &now
-			s.logger.Info().
-				Str("url", subscriptions[i].Url).
-				Uint("match_id", subscriptions[i].MatchID).
-				Msg("subscriber successfully notified")
-		}
+	return repository.SuccessfulSub
+}
 
-		errUpdate := s.subscriptionRepository.Update(ctx, subscriptions[i].ID, toUpdate)
-		if errUpdate != nil {
-			return fmt.Errorf("failed to update subscription status to %s: %w", toUpdate.Status, errUpdate)
-		}
+// toNotifiedSubscription maps the subscription and checks that its match has the result to send.
+func toNotifiedSubscription(subscription repository.Subscription) (*Subscription, error) {
+	mapped, err := fromRepositorySubscription(subscription)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map repository subscription: %w", err)
 	}
 
-	return nil
+	if mapped.Match == nil {
+		return nil, fmt.Errorf("match of the subscription %d is not found", mapped.ID)
+	}
+
+	if len(mapped.Match.FootballApiFixtures) == 0 {
+		return nil, fmt.Errorf("football api fixtures of the match with id %d is not found", mapped.MatchID)
+	}
+
+	return mapped, nil
+}
+
+// hostLimiter limits the number of parallel deliveries to each host.
+type hostLimiter struct {
+	limit uint
+	mutex sync.Mutex
+	slots map[string]chan struct{}
+}
+
+func newHostLimiter(limit uint) *hostLimiter {
+	return &hostLimiter{limit: limit, slots: map[string]chan struct{}{}}
+}
+
+func (l *hostLimiter) acquire(host string) {
+	l.mutex.Lock()
+	slots, ok := l.slots[host]
+	if !ok {
+		slots = make(chan struct{}, l.limit)
+		l.slots[host] = slots
+	}
+	l.mutex.Unlock()
+
+	slots <- struct{}{}
+}
+
+func (l *hostLimiter) release(host string) {
+	l.mutex.Lock()
+	slots := l.slots[host]
+	l.mutex.Unlock()
+
+	<-slots
+}
+
+func subscriptionHost(subscriptionURL string) string {
+	parsed, err := url.Parse(subscriptionURL)
+	if err != nil || parsed.Host == "" {
+		return subscriptionURL
+	}
+
+	return parsed.Host
 }
@@ -0,0 +1,265 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andrewshostak/result-service/client"
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/andrewshostak/result-service/errs"
+	"github.com/andrewshostak/result-service/metrics"
+	"github.com/andrewshostak/result-service/repository"
+	"github.com/andrewshostak/result-service/service"
+	"github.com/andrewshostak/result-service/service/mocks"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/jackc/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNotifierService_NotifySubscribers(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, time.January, 20, 17, 0, 0, 0, time.UTC)
+
+	subscription := func(id uint, match *repository.Match) repository.Subscription {
+		return repository.Subscription{ID: id, MatchID: uint(gofakeit.Uint8()), Url: gofakeit.URL(), Key: gofakeit.Password(true, true, true, false, false, 10), Status: repository.PendingSub, Match: match}
+	}
+
+	t.Run("it should mark subscriptions which can't be notified as failed and notify the rest", func(t *testing.T) {
+		outboxRepository := mocks.NewOutboxRepository(t)
+		notifierClient := mocks.NewNotifierClient(t)
+		logger := mocks.NewLogger(t)
+
+		logger.On("Info").Return(nil)
+		logger.On("Error").Return(nil)
+
+		withoutMatch := subscription(1, nil)
+		withoutFixtures := subscription(2, &repository.Match{ID: 2})
+		failing := subscription(3, fakeRepositoryMatchWithResult(2, 1))
+		valid := subscription(4, fakeRepositoryMatchWithResult(0, 3))
+
+		outboxRepository.On("Claim", mock.Anything, now, now.Add(10*time.Minute), 20).Return(outboxNotifications(withoutMatch, withoutFixtures, failing, valid), nil).Once()
+		notifierClient.On("Notify", mock.Anything, client.Notification{Url: failing.Url, Key: failing.Key, Home: 2, Away: 1}).Return(errors.New("timeout")).Once()
+		notifierClient.On("Notify", mock.Anything, client.Notification{Url: valid.Url, Key: valid.Key, Home: 0, Away: 3}).Return(nil).Once()
+		for _, id := range []uint{1, 2, 3} {
+			outboxRepository.On("CompleteInTrx", mock.Anything, outboxNotificationOf(id), repository.Subscription{Status: repository.ErrorSub}).Return(nil).Once()
+		}
+		outboxRepository.On("CompleteInTrx", mock.Anything, outboxNotificationOf(4), repository.Subscription{Status: repository.SuccessfulSub, NotifiedAt: &now}).Return(nil).Once()
+
+		s := service.NewNotifierService(outboxRepository, notifierClient, logger, clock.NewFake(now), metrics.New(), service.NotifierConcurrency{Workers: 2, PerHost: 1, DeliveryTimeout: 30 * time.Second})
+		assert.NoError(t, s.NotifySubscribers(ctx))
+	})
+
+	t.Run("it should continue with other subscriptions and return an error when a status is not saved", func(t *testing.T) {
+		outboxRepository := mocks.NewOutboxRepository(t)
+		notifierClient := mocks.NewNotifierClient(t)
+		logger := mocks.NewLogger(t)
+
+		logger.On("Info").Return(nil)
+
+		first := subscription(1, fakeRepositoryMatchWithResult(1, 1))
+		second := subscription(2, fakeRepositoryMatchWithResult(1, 1))
+
+		outboxRepository.On("Claim", mock.Anything, now, now.Add(10*time.Minute), 20).Return(outboxNotifications(first, second), nil).Once()
+		notifierClient.On("Notify", mock.Anything, mock.Anything).Return(nil).Twice()
+		outboxRepository.On("CompleteInTrx", mock.Anything, outboxNotificationOf(1), mock.Anything).Return(errors.New("connection refused")).Once()
+		outboxRepository.On("CompleteInTrx", mock.Anything, outboxNotificationOf(2), mock.Anything).Return(nil).Once()
+
+		s := service.NewNotifierService(outboxRepository, notifierClient, logger, clock.NewFake(now), metrics.New(), service.NotifierConcurrency{Workers: 2, PerHost: 1, DeliveryTimeout: 30 * time.Second})
+		assert.EqualError(t, s.NotifySubscribers(ctx), "failed to update status of subscription 1 to successful: connection refused")
+	})
+
+	t.Run("it should limit parallel deliveries per host without blocking other hosts", func(t *testing.T) {
+		outboxRepository := mocks.NewOutboxRepository(t)
+		notifierClient := mocks.NewNotifierClient(t)
+		logger := mocks.NewLogger(t)
+
+		logger.On("Info").Return(nil)
+
+		var subscriptions []repository.Subscription
+		for i := uint(1); i <= 4; i++ {
+			sub := subscription(i, fakeRepositoryMatchWithResult(1, 0))
+			sub.Url = fmt.Sprintf("https://slow.example.com/results/%d", i)
+			subscriptions = append(subscriptions, sub)
+		}
+		fast := subscription(5, fakeRepositoryMatchWithResult(1, 0))
+		fast.Url = "https://fast.example.com/results"
+		subscriptions = append(subscriptions, fast)
+
+		var mutex sync.Mutex
+		inFlight := map[string]int{}
+		maxInFlight := map[string]int{}
+		fastNotified := make(chan struct{})
+
+		outboxRepository.On("Claim", mock.Anything, now, now.Add(10*time.Minute), 30).Return(outboxNotifications(subscriptions...), nil).Once()
+		outboxRepository.On("CompleteInTrx", mock.Anything, mock.Anything, mock.Anything).Return(nil).Times(5)
+		notifierClient.On("Notify", mock.Anything, mock.Anything).Return(nil).Times(5).Run(func(args mock.Arguments) {
+			host := strings.Split(args.Get(1).(client.Notification).Url, "/")[2]
+
+			mutex.Lock()
+			inFlight[host]++
+			maxInFlight[host] = max(maxInFlight[host], inFlight[host])
+			mutex.Unlock()
+
+			if host == "fast.example.com" {
+				close(fastNotified)
+			} else {
+				// slow deliveries wait until the fast one is done, so the run finishes only when they don't block it
+				<-fastNotified
+				time.Sleep(5 * time.Millisecond)
+			}
+
+			mutex.Lock()
+			inFlight[host]--
+			mutex.Unlock()
+		})
+
+		s := service.NewNotifierService(outboxRepository, notifierClient, logger, clock.NewFake(now), metrics.New(), service.NotifierConcurrency{Workers: 3, PerHost: 2, DeliveryTimeout: 30 * time.Second})
+		done := make(chan error)
+		go func() {
+			done <- s.NotifySubscribers(ctx)
+		}()
+
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("fast subscriber is blocked by slow deliveries")
+		}
+		assert.Equal(t, 2, maxInFlight["slow.example.com"])
+		assert.Equal(t, 1, maxInFlight["fast.example.com"])
+	})
+
+	t.Run("it should claim the next batch when the claimed batch is full", func(t *testing.T) {
+		outboxRepository := mocks.NewOutboxRepository(t)
+		notifierClient := mocks.NewNotifierClient(t)
+		logger := mocks.NewLogger(t)
+
+		logger.On("Info").Return(nil)
+
+		var batch []repository.Subscription
+		for i := uint(1); i <= 10; i++ {
+			batch = append(batch, subscription(i, fakeRepositoryMatchWithResult(1, 0)))
+		}
+		last := subscription(11, fakeRepositoryMatchWithResult(1, 0))
+
+		// a worker makes 10 deliveries limited by 30 seconds, so the lease is twice as long
+		outboxRepository.On("Claim", mock.Anything, now, now.Add(10*time.Minute), 10).Return(outboxNotifications(batch...), nil).Once()
+		outboxRepository.On("Claim", mock.Anything, now, now.Add(10*time.Minute), 10).Return(outboxNotifications(last), nil).Once()
+		notifierClient.On("Notify", mock.Anything, mock.Anything).Return(nil).Times(11)
+		outboxRepository.On("CompleteInTrx", mock.Anything, mock.Anything, mock.Anything).Return(nil).Times(11)
+
+		s := service.NewNotifierService(outboxRepository, notifierClient, logger, clock.NewFake(now), metrics.New(), service.NotifierConcurrency{Workers: 1, PerHost: 1, DeliveryTimeout: 30 * time.Second})
+		assert.NoError(t, s.NotifySubscribers(ctx))
+	})
+
+	t.Run("it should leave the delivery to the next run when the lease expires before it is finished", func(t *testing.T) {
+		outboxRepository := mocks.NewOutboxRepository(t)
+		logger := mocks.NewLogger(t)
+
+		logger.On("Info").Return(nil)
+		logger.On("Warn").Return(nil).Once()
+
+		notifications := outboxNotifications(subscription(1, fakeRepositoryMatchWithResult(1, 0)))
+		lockedUntil := now.Add(20 * time.Second)
+		notifications[0].LockedUntil = &lockedUntil
+
+		outboxRepository.On("Claim", mock.Anything, now, now.Add(10*time.Minute), 20).Return(notifications, nil).Once()
+
+		s := service.NewNotifierService(outboxRepository, mocks.NewNotifierClient(t), logger, clock.NewFake(now), metrics.New(), service.NotifierConcurrency{Workers: 2, PerHost: 1, DeliveryTimeout: 30 * time.Second})
+		assert.NoError(t, s.NotifySubscribers(ctx))
+	})
+
+	t.Run("it should not return an error when the notification is claimed again after the lease", func(t *testing.T) {
+		outboxRepository := mocks.NewOutboxRepository(t)
+		notifierClient := mocks.NewNotifierClient(t)
+		logger := mocks.NewLogger(t)
+
+		logger.On("Info").Return(nil)
+		logger.On("Warn").Return(nil).Once()
+
+		outboxRepository.On("Claim", mock.Anything, now, now.Add(10*time.Minute), 20).Return(outboxNotifications(subscription(1, fakeRepositoryMatchWithResult(1, 0))), nil).Once()
+		notifierClient.On("Notify", mock.Anything, mock.Anything).Return(nil).Once()
+		outboxRepository.On("CompleteInTrx", mock.Anything, outboxNotificationOf(1), mock.Anything).
+			Return(fmt.Errorf("outbox notification 101: %w", errs.ErrNotificationLeaseExpired)).Once()
+
+		s := service.NewNotifierService(outboxRepository, notifierClient, logger, clock.NewFake(now), metrics.New(), service.NotifierConcurrency{Workers: 2, PerHost: 1, DeliveryTimeout: 30 * time.Second})
+		assert.NoError(t, s.NotifySubscribers(ctx))
+	})
+
+	t.Run("it should return an error when notifications can't be claimed", func(t *testing.T) {
+		outboxRepository := mocks.NewOutboxRepository(t)
+		outboxRepository.On("Claim", mock.Anything, now, now.Add(10*time.Minute), 20).Return(nil, errors.New("connection refused")).Once()
+
+		s := service.NewNotifierService(outboxRepository, mocks.NewNotifierClient(t), mocks.NewLogger(t), clock.NewFake(now), metrics.New(), service.NotifierConcurrency{Workers: 2, PerHost: 1, DeliveryTimeout: 30 * time.Second})
+		assert.EqualError(t, s.NotifySubscribers(ctx), "failed to claim notifications: connection refused")
+	})
+}
+
+func TestNotifierService_NotifyMatchSubscribers(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, time.January, 20, 17, 0, 0, 0, time.UTC)
+
+	t.Run("it should notify only subscribers of the match", func(t *testing.T) {
+		outboxRepository := mocks.NewOutboxRepository(t)
+		notifierClient := mocks.NewNotifierClient(t)
+		logger := mocks.NewLogger(t)
+
+		logger.On("Info").Return(nil)
+
+		match := fakeRepositoryMatchWithResult(2, 0)
+		sub := repository.Subscription{ID: 5, MatchID: match.ID, Url: gofakeit.URL(), Key: gofakeit.Password(true, true, true, false, false, 10), Status: repository.PendingSub, Match: match}
+
+		outboxRepository.On("ClaimByMatch", mock.Anything, match.ID, now, now.Add(10*time.Minute), 20).Return(outboxNotifications(sub), nil).Once()
+		notifierClient.On("Notify", mock.Anything, client.Notification{Url: sub.Url, Key: sub.Key, Home: 2, Away: 0}).Return(nil).Once()
+		outboxRepository.On("CompleteInTrx", mock.Anything, outboxNotificationOf(5), repository.Subscription{Status: repository.SuccessfulSub, NotifiedAt: &now}).Return(nil).Once()
+
+		s := service.NewNotifierService(outboxRepository, notifierClient, logger, clock.NewFake(now), metrics.New(), service.NotifierConcurrency{Workers: 2, PerHost: 1, DeliveryTimeout: 30 * time.Second})
+		assert.NoError(t, s.NotifyMatchSubscribers(ctx, match.ID))
+	})
+
+	t.Run("it should return an error when notifications of the match can't be claimed", func(t *testing.T) {
+		outboxRepository := mocks.NewOutboxRepository(t)
+		outboxRepository.On("ClaimByMatch", mock.Anything, uint(3), now, now.Add(10*time.Minute), 20).Return(nil, errors.New("connection refused")).Once()
+
+		s := service.NewNotifierService(outboxRepository, mocks.NewNotifierClient(t), mocks.NewLogger(t), clock.NewFake(now), metrics.New(), service.NotifierConcurrency{Workers: 2, PerHost: 1, DeliveryTimeout: 30 * time.Second})
+		assert.EqualError(t, s.NotifyMatchSubscribers(ctx, 3), "failed to claim notifications of match 3: connection refused")
+	})
+}
+
+func outboxNotifications(subscriptions ...repository.Subscription) []repository.OutboxNotification {
+	notifications := make([]repository.OutboxNotification, 0, len(subscriptions))
+	for i := range subscriptions {
+		notifications = append(notifications, repository.OutboxNotification{
+			ID:             subscriptions[i].ID + 100,
+			SubscriptionID: subscriptions[i].ID,
+			MatchID:        subscriptions[i].MatchID,
+			Subscription:   &subscriptions[i],
+		})
+	}
+
+	return notifications
+}
+
+func outboxNotificationOf(subscriptionID uint) any {
+	return mock.MatchedBy(func(notification repository.OutboxNotification) bool {
+		return notification.SubscriptionID == subscriptionID
+	})
+}
+
+func fakeRepositoryMatchWithResult(home uint, away uint) *repository.Match {
+	id := uint(gofakeit.Uint8())
+	data := pgtype.JSONB{}
+	_ = data.UnmarshalJSON([]byte(footballAPIFixtureRaw(home, away)))
+
+	return &repository.Match{
+		ID:                  id,
+		ResultStatus:        repository.Successful,
+		FootballApiFixtures: []repository.FootballApiFixture{{ID: uint(gofakeit.Uint16()), MatchID: id, Data: data}},
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	statusFinished               = "FT"
+	statusFinishedAfterExtraTime = "AET"
+	statusFinishedAfterPenalties = "PEN"
+	statusFirstHalf              = "1H"
+	statusHalfTime               = "HT"
+	statusSecondHalf             = "2H"
+	statusBreakTime              = "BT"
+	statusExtraTime              = "ET"
+	statusPenalties              = "P"
+	statusLive                   = "LIVE"
+	statusInterrupted            = "INT"
+)
+
+// outcomes of attempts to get the match result reported to metrics
+const (
+	pollingOutcomeFinished    = "finished"
+	pollingOutcomeInProgress  = "in_progress"
+	pollingOutcomeNotFinished = "not_finished"
+	pollingOutcomeError       = "error"
+	pollingOutcomeUnavailable = "unavailable"
+)
+
+// minutes of match time after which the end of a period is expected
+const (
+	secondHalfEndingMinute = 85
+	extraTimeEndingMinute  = 115
+	halfTimeMinutes        = 15
+)
+
+// isFinished reports whether the match is over. The short status is used, since the long one differs for matches
+// finished after extra time and penalties.
+func isFinished(status Status) bool {
+	switch status.Short {
+	case statusFinished, statusFinishedAfterExtraTime, statusFinishedAfterPenalties:
+		return true
+	default:
+		return false
+	}
+}
+
+// nextAttemptDelay returns the delay before the next attempt to get the result and whether the match is in progress.
+// The match is polled more often near the end of the second half and the extra time, and less often during the breaks.
+func (s *MatchService) nextAttemptDelay(status Status) (time.Duration, bool) {
+	elapsed := uint(0)
+	if status.Elapsed != nil {
+		elapsed = *status.Elapsed
+	}
+
+	switch status.Short {
+	case statusFirstHalf:
+		return s.clampDelay(minutesUntil(secondHalfEndingMinute+halfTimeMinutes, elapsed)), true
+	case statusHalfTime:
+		return s.livePolling.HalfTimeInterval, true
+	case statusSecondHalf:
+		return s.clampDelay(minutesUntil(secondHalfEndingMinute, elapsed)), true
+	case statusBreakTime:
+		return s.livePolling.HalfTimeInterval, true
+	case statusExtraTime:
+		return s.clampDelay(minutesUntil(extraTimeEndingMinute, elapsed)), true
+	case statusPenalties, statusLive, statusInterrupted:
+		return s.livePolling.Interval, true
+	default:
+		return s.pollingInterval, false
+	}
+}
+
+// livePollingAllowed reports whether attempts made while the match is in progress may be not counted in the retries limit.
+// A match stuck in a live status (e.g. interrupted and never resumed) is polled without counting only within LivePolling.MaxDuration from its start.
+func (s *MatchService) livePollingAllowed(matchDetails matchLogFields) bool {
+	return s.clock.Now().Before(matchDetails.startsAt.Add(s.livePolling.MaxDuration))
+}
+
+// clampDelay keeps the delay between the live polling interval and the regular polling interval.
+func (s *MatchService) clampDelay(delay time.Duration) time.Duration {
+	return max(s.livePolling.Interval, min(delay, s.pollingInterval))
+}
+
+// firstAttemptDelay returns a longer delay for knockout rounds, since they may have extra time and penalties.
+func (s *MatchService) firstAttemptDelay(round string) time.Duration {
+	if isKnockoutRound(round) {
+		return s.livePolling.KnockoutFirstAttemptDelay
+	}
+
+	return s.pollingFirstAttemptDelay
+}
+
+// isKnockoutRound detects knockout rounds by football-api round names, e.g. "Round of 16", "Quarter-finals", "Play-offs".
+// League rounds are named like "Regular Season - 10", "Group A - 2" or "Championship Round - 3".
+func isKnockoutRound(round string) bool {
+	round = strings.ToLower(round)
+	for _, keyword := range []string{"round of", "final", "play-off", "playoff", "knockout"} {
+		if strings.Contains(round, keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func minutesUntil(minute uint, elapsed uint) time.Duration {
+	if elapsed >= minute {
+		return 0
+	}
+
+	return time.Duration(minute-elapsed) * time.Minute
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/andrewshostak/result-service/client"
+	"github.com/andrewshostak/result-service/errs"
+)
+
+const (
+	ProviderFootballAPI = "api-football"
+	ProviderResultFeed  = "result-feed"
+)
+
+type FootballAPIResultProvider struct {
+	fixtureBatcher FixtureBatcher
+}
+
+func NewFootballAPIResultProvider(fixtureBatcher FixtureBatcher) *FootballAPIResultProvider {
+	return &FootballAPIResultProvider{fixtureBatcher: fixtureBatcher}
+}
+
+func (p *FootballAPIResultProvider) ID() string {
+	return ProviderFootballAPI
+}
+
+func (p *FootballAPIResultProvider) Result(ctx context.Context, query ResultQuery) (*Data, error) {
+	result, err := p.fixtureBatcher.Fixture(client.WithPriority(ctx, client.PriorityUrgent), query.Fixture.Fixture.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fixture: %w", err)
+	}
+
+	fixture := fromClientFootballAPIFixture(*result)
+
+	return &fixture, nil
+}
+
+// ResultFeedProvider finds a match in the generic JSON feed by kick-off date and team names.
+type ResultFeedProvider struct {
+	resultFeedClient ResultFeedClient
+}
+
+func NewResultFeedProvider(resultFeedClient ResultFeedClient) *ResultFeedProvider {
+	return &ResultFeedProvider{resultFeedClient: resultFeedClient}
+}
+
+func (p *ResultFeedProvider) ID() string {
+	return ProviderResultFeed
+}
+
+func (p *ResultFeedProvider) Result(ctx context.Context, query ResultQuery) (*Data, error) {
+	startsAt, err := time.Parse(time.RFC3339, query.Fixture.Fixture.Date)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse fixture date %s: %w", query.Fixture.Fixture.Date, err)
+	}
+
+	response, err := p.resultFeedClient.SearchMatches(ctx, startsAt.UTC().Format(dateFormat))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search result feed matches: %w", err)
+	}
+
+	homeNames := append([]string{query.Fixture.Teams.Home.Name}, query.HomeAliases...)
+	awayNames := append([]string{query.Fixture.Teams.Away.Name}, query.AwayAliases...)
+
+	for _, match := range response.Matches {
+		if !containsName(homeNames, match.HomeTeam) || !containsName(awayNames, match.AwayTeam) {
+			continue
+		}
+
+		data := query.Fixture
+		data.Fixture.Status = fromResultFeedStatus(match.Status)
+		data.Goals = Goals{Home: match.HomeGoals, Away: match.AwayGoals}
+
+		return &data, nil
+	}
+
+	return nil, fmt.Errorf("match between %s and %s: %w", query.Fixture.Teams.Home.Name, query.Fixture.Teams.Away.Name, errs.ErrResultNotFound)
+}
+
+func fromResultFeedStatus(status string) Status {
+	switch status {
+	case "finished":
+		return Status{Short: statusFinished, Long: stateMatchFinished}
+	case "live":
+		return Status{Short: statusLive, Long: "In Progress"}
+	case "postponed":
+		return Status{Short: "PST", Long: "Match Postponed"}
+	case "cancelled":
+		return Status{Short: "CANC", Long: "Match Cancelled"}
+	default:
+		return Status{Short: "NS", Long: "Not Started"}
+	}
+}
+
+func containsName(names []string, name string) bool {
+	for i := range names {
+		if strings.EqualFold(strings.TrimSpace(names[i]), strings.TrimSpace(name)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,66 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andrewshostak/result-service/client"
+	"github.com/andrewshostak/result-service/errs"
+	"github.com/andrewshostak/result-service/service"
+	"github.com/andrewshostak/result-service/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultFeedProvider_Result(t *testing.T) {
+	const feed = `{"matches": [
+		{"id": "1", "home_team": "Arsenal", "away_team": "Chelsea", "starts_at": "2023-12-09T15:00:00Z", "status": "finished", "home_goals": 2, "away_goals": 2},
+		{"id": "2", "home_team": "Man United", "away_team": "Bournemouth", "starts_at": "2023-12-09T15:00:00Z", "status": "finished", "home_goals": 0, "away_goals": 3}
+	]}`
+
+	var requestedDate, requestedKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedDate = r.URL.Query().Get("date")
+		requestedKey = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(feed))
+	}))
+	defer server.Close()
+
+	logger := mocks.NewLogger(t)
+	provider := service.NewResultFeedProvider(client.NewResultFeedClient(server.Client(), logger, server.URL, "secret"))
+	ctx := context.Background()
+
+	query := service.ResultQuery{
+		Fixture: service.Data{
+			Fixture: service.Fixture{ID: 1035330, Date: "2023-12-09T17:00:00+02:00", Status: service.Status{Short: "NS", Long: "Not Started"}},
+			Teams: service.TeamsExternal{
+				Home: service.TeamExternal{ID: 33, Name: "Manchester United"},
+				Away: service.TeamExternal{ID: 35, Name: "Bournemouth"},
+			},
+		},
+		HomeAliases: []string{"man united"},
+		AwayAliases: []string{"AFC Bournemouth"},
+	}
+
+	t.Run("it should return fixture data with status and goals of the match found by team names", func(t *testing.T) {
+		result, err := provider.Result(ctx, query)
+		assert.NoError(t, err)
+		assert.Equal(t, "2023-12-09", requestedDate)
+		assert.Equal(t, "secret", requestedKey)
+
+		expected := query.Fixture
+		expected.Fixture.Status = service.Status{Short: "FT", Long: "Match Finished"}
+		expected.Goals = service.Goals{Home: 0, Away: 3}
+		assert.Equal(t, &expected, result)
+	})
+
+	t.Run("it should return not found error when the feed doesn't have the match", func(t *testing.T) {
+		notFoundQuery := query
+		notFoundQuery.HomeAliases = nil
+
+		result, err := provider.Result(ctx, notFoundQuery)
+		assert.ErrorIs(t, err, errs.ErrResultNotFound)
+		assert.Nil(t, result)
+	})
+}
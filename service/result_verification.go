@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/andrewshostak/result-service/errs"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// verifyWithProviders confirms the result with another provider. When the result cannot be verified yet,
+// the attempt is counted, and false is returned, so the result is requested again on the next polling attempt.
+func (s *MatchService) verifyWithProviders(
+	ctx context.Context,
+	attempt *int,
+	ch chan<- resultTaskChan,
+	query ResultQuery,
+	fixture Data,
+	provider string,
+	matchDetails matchLogFields,
+) bool {
+	verified, verificationProvider, err := s.getVerificationResult(ctx, query, provider)
+	if err == nil && !isFinished(verified.Fixture.Status) {
+		err = fmt.Errorf("match status is %s according to %s", verified.Fixture.Status.Long, verificationProvider)
+	}
+
+	if err != nil {
+		enrichLogWithMatchDetails(s.logger.Error(), matchDetails).Err(err).Msg("failed to verify match result with another provider")
+		*attempt++
+
+		if s.retriesLimitReached(*attempt) {
+			s.writeNeedsReview(ch, fixture, provider, matchDetails, "retries limit reached before the result is verified")
+			return true
+		}
+		return false
+	}
+
+	if !equalGoals(fixture, *verified) {
+		s.writeNeedsReview(ch, fixture, provider, matchDetails, fmt.Sprintf(
+			"%s returned %d:%d, but %s returned %d:%d",
+			provider, fixture.Goals.Home, fixture.Goals.Away,
+			verificationProvider, verified.Goals.Home, verified.Goals.Away,
+		))
+		return true
+	}
+
+	enrichLogWithMatchDetails(s.logger.Info(), matchDetails).Str("verification_provider", verificationProvider).Msg("match result verified")
+	s.writeResult(ch, fixture, provider, matchDetails)
+	return true
+}
+
+// scheduleDelayedVerification replaces the polling task with a one-time task reading the result again after the delay.
+func (s *MatchService) scheduleDelayedVerification(
+	key string,
+	attempt *int,
+	ch chan<- resultTaskChan,
+	query ResultQuery,
+	fixture Data,
+	provider string,
+	matchDetails matchLogFields,
+) {
+	startTime := s.clock.Now().Add(s.resultVerification.Delay)
+	task := s.getVerificationTaskFunc(key, attempt, ch, query, fixture, provider, matchDetails)
+
+	err := s.taskScheduler.ScheduleOnce(key, task, startTime)
+	if errors.Is(err, errs.ErrShuttingDown) {
+		enrichLogWithMatchDetails(s.logger.Warn(), matchDetails).Msg("match result verification is not scheduled because of shutdown. the result is requested again on start")
+		return
+	}
+
+	if err != nil {
+		enrichLogWithMatchDetails(s.logger.Error(), matchDetails).Err(err).Msg("failed to schedule match result verification")
+		s.writeNeedsReview(ch, fixture, provider, matchDetails, "failed to schedule result verification")
+		return
+	}
+
+	enrichLogWithMatchDetails(s.logger.Info(), matchDetails).
+		Uint("home", fixture.Goals.Home).
+		Uint("away", fixture.Goals.Away).
+		Time("verification_at", startTime).
+		Msg("match result received. verification is scheduled")
+}
+
+func (s *MatchService) getVerificationTaskFunc(
+	key string,
+	attempt *int,
+	ch chan<- resultTaskChan,
+	query ResultQuery,
+	fixture Data,
+	provider string,
+	matchDetails matchLogFields,
+) func(c context.Context) {
+	return func(c context.Context) {
+		c, span := tracer.Start(c, "verify match result", withCreatorLink(matchDetails.creator),
+			trace.WithAttributes(attribute.Int64("match.id", int64(matchDetails.matchID))))
+		defer span.End()
+
+		enrichLogWithMatchDetails(s.logger.Info(), matchDetails).Msg("reading match result again to verify it")
+
+		verified, verificationProvider, err := s.getResult(c, query, matchDetails)
+		if err != nil && (errors.Is(err, errs.ErrShuttingDown) || c.Err() != nil) {
+			enrichLogWithMatchDetails(s.logger.Warn(), matchDetails).Err(err).Msg("match result verification is interrupted by shutdown. the result is requested again on start")
+			return
+		}
+
+		if err != nil {
+			enrichLogWithMatchDetails(s.logger.Error(), matchDetails).Err(err).Msg("failed to read match result to verify it")
+			counted := !unavailable(err) || !s.uncountedAttemptAllowed(matchDetails)
+			s.retryDelayedVerification(key, attempt, counted, ch, query, fixture, provider, matchDetails)
+			return
+		}
+
+		// the provider may lag behind or report another final status (e.g. FT -> AET), so the result is read again
+		if !isFinished(verified.Fixture.Status) {
+			enrichLogWithMatchDetails(s.logger.Warn(), matchDetails).
+				Str("status", verified.Fixture.Status.Short).
+				Msg("match is not finished according to the second read. it is read again")
+			s.retryDelayedVerification(key, attempt, true, ch, query, fixture, provider, matchDetails)
+			return
+		}
+
+		if !equalGoals(fixture, *verified) {
+			s.writeNeedsReview(ch, fixture, provider, matchDetails, fmt.Sprintf(
+				"first read returned %d:%d, but second read returned %d:%d",
+				fixture.Goals.Home, fixture.Goals.Away,
+				verified.Goals.Home, verified.Goals.Away,
+			))
+			return
+		}
+
+		s.writeResult(ch, *verified, verificationProvider, matchDetails)
+	}
+}
+
+// retryDelayedVerification schedules the next read of the result. The match needs review when the retries limit is reached.
+func (s *MatchService) retryDelayedVerification(
+	key string,
+	attempt *int,
+	counted bool,
+	ch chan<- resultTaskChan,
+	query ResultQuery,
+	fixture Data,
+	provider string,
+	matchDetails matchLogFields,
+) {
+	if counted {
+		*attempt++
+	}
+
+	if s.retriesLimitReached(*attempt) {
+		s.writeNeedsReview(ch, fixture, provider, matchDetails, "retries limit reached before the result is verified")
+		return
+	}
+
+	s.scheduleDelayedVerification(key, attempt, ch, query, fixture, provider, matchDetails)
+}
+
+// getVerificationResult requests the result from providers other than the one that returned the result.
+func (s *MatchService) getVerificationResult(ctx context.Context, query ResultQuery, provider string) (*Data, string, error) {
+	var providerErrors []error
+	for _, p := range s.resultProviders {
+		if p.ID() == provider {
+			continue
+		}
+
+		fixture, err := p.Result(ctx, query)
+		if err == nil {
+			return fixture, p.ID(), nil
+		}
+
+		providerErrors = append(providerErrors, fmt.Errorf("%s: %w", p.ID(), err))
+	}
+
+	if len(providerErrors) == 0 {
+		return nil, "", errors.New("there is no other result provider to verify the result")
+	}
+
+	return nil, "", errors.Join(providerErrors...)
+}
+
+func (s *MatchService) writeNeedsReview(ch chan<- resultTaskChan, fixture Data, provider string, matchDetails matchLogFields, reason string) {
+	enrichLogWithMatchDetails(s.logger.Error(), matchDetails).
+		Uint("home", fixture.Goals.Home).
+		Uint("away", fixture.Goals.Away).
+		Str("provider", provider).
+		Str("reason", reason).
+		Msg("match result is not verified. it needs review")
+	ch <- resultTaskChan{fixture: &fixture, provider: provider, needsReview: true}
+	close(ch)
+}
+
+func equalGoals(a Data, b Data) bool {
+	return a.Goals.Home == b.Goals.Home && a.Goals.Away == b.Goals.Away
+}
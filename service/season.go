@@ -0,0 +1,26 @@
+package service
+
+import "time"
+
+// getSeason returns current year if current time is after June 3, otherwise previous year
+func getSeason(t time.Time) int {
+	if t.After(getSeasonBound(t.Year())) {
+		return t.Year()
+	}
+
+	return t.AddDate(-1, 0, 0).Year()
+}
+
+// getNextSeasonStart returns the first moment when getSeason starts returning the next season
+func getNextSeasonStart(t time.Time) time.Time {
+	bound := getSeasonBound(t.Year())
+	if t.After(bound) {
+		bound = getSeasonBound(t.Year() + 1)
+	}
+
+	return bound.Add(time.Nanosecond)
+}
+
+func getSeasonBound(year int) time.Time {
+	return time.Date(year, 6, 3, 0, 0, 0, 0, time.UTC)
+}
@@ -4,9 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/andrewshostak/result-service/errs"
+	loggerinternal "github.com/andrewshostak/result-service/logger"
 	"github.com/andrewshostak/result-service/repository"
 )
 
@@ -16,6 +16,7 @@ type SubscriptionService struct {
 	aliasRepository        AliasRepository
 	taskScheduler          TaskScheduler
 	logger                 Logger
+	clock                  Clock
 }
 
 func NewSubscriptionService(
@@ -24,6 +25,7 @@ func NewSubscriptionService(
 	aliasRepository AliasRepository,
 	taskScheduler TaskScheduler,
 	logger Logger,
+	clock Clock,
 ) *SubscriptionService {
 	return &SubscriptionService{
 		subscriptionRepository: subscriptionRepository,
@@ -31,6 +33,7 @@ func NewSubscriptionService(
 		aliasRepository:        aliasRepository,
 		taskScheduler:          taskScheduler,
 		logger:                 logger,
+		clock:                  clock,
 	}
 }
 
@@ -47,7 +50,7 @@ func (s *SubscriptionService) Create(ctx context.Context, request CreateSubscrip
 	_, err = s.subscriptionRepository.Create(ctx, repository.Subscription{
 		MatchID:   request.MatchID,
 		Key:       request.SecretKey,
-		CreatedAt: time.Now(),
+		CreatedAt: s.clock.Now(),
 		Url:       request.URL,
 	})
 
@@ -59,6 +62,8 @@ func (s *SubscriptionService) Create(ctx context.Context, request CreateSubscrip
 }
 
 func (s *SubscriptionService) Delete(ctx context.Context, request DeleteSubscriptionRequest) error {
+	logger := loggerinternal.WithRequestID(ctx, s.logger)
+
 	aliasHome, err := s.aliasRepository.Find(ctx, request.AliasHome)
 	if err != nil {
 		return fmt.Errorf("failed to find home team alias: %w", err)
@@ -97,29 +102,29 @@ func (s *SubscriptionService) Delete(ctx context.Context, request DeleteSubscrip
 		return fmt.Errorf("failed to delete subscription: %w", err)
 	}
 
-	s.logger.Info().Uint("subscription_id", subscription.ID).Msg("subscription deleted")
+	logger.Info().Uint("subscription_id", subscription.ID).Msg("subscription deleted")
 
 	otherSubscriptions, errList := s.subscriptionRepository.List(ctx, match.ID)
 	if errList != nil {
-		s.logger.Error().Err(err).Uint("match_id", match.ID).Msg("failed to check other subscriptions presence")
+		logger.Error().Err(err).Uint("match_id", match.ID).Msg("failed to check other subscriptions presence")
 		return nil
 	}
 
 	if len(otherSubscriptions) > 0 {
-		s.logger.Info().Uint("match_id", match.ID).Msg("there are other subscriptions for the match. no need to cancel result acquiring task")
+		logger.Info().Uint("match_id", match.ID).Msg("there are other subscriptions for the match. no need to cancel result acquiring task")
 		return nil
 	}
 
 	errDelete := s.matchRepository.Delete(ctx, match.ID)
 	if errDelete != nil {
-		s.logger.Error().Err(errDelete).Uint("match_id", match.ID).Msg("failed to delete match")
+		logger.Error().Err(errDelete).Uint("match_id", match.ID).Msg("failed to delete match")
 		return nil
 	}
 
-	s.logger.Info().Uint("match_id", match.ID).Msg("match deleted")
+	logger.Info().Uint("match_id", match.ID).Msg("match deleted")
 
 	if len(match.FootballApiFixtures) < 1 {
-		s.logger.Error().Uint("match_id", match.ID).Msg("failed to cancel scheduled task: match relation football api fixtures is not found")
+		logger.Error().Uint("match_id", match.ID).Msg("failed to cancel scheduled task: match relation football api fixtures is not found")
 		return nil
 	}
 
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/andrewshostak/result-service/service")
+
+// creatorSpanContext restores the span context of the request which created the match.
+// It is invalid when the match was created without tracing.
+func creatorSpanContext(match Match) trace.SpanContext {
+	traceID, err := trace.TraceIDFromHex(match.TraceID)
+	if err != nil {
+		return trace.SpanContext{}
+	}
+
+	spanID, err := trace.SpanIDFromHex(match.SpanID)
+	if err != nil {
+		return trace.SpanContext{}
+	}
+
+	return trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, Remote: true})
+}
+
+// withCreatorLink links background spans (e.g. polling attempts) to the request which created the match.
+func withCreatorLink(creator trace.SpanContext) trace.SpanStartOption {
+	if !creator.IsValid() {
+		return trace.WithLinks()
+	}
+
+	return trace.WithLinks(trace.Link{SpanContext: creator})
+}
+
+// traceIDs returns ids of the current span to save them with the match.
+func traceIDs(ctx context.Context) (*string, *string) {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil, nil
+	}
+
+	traceID, spanID := spanContext.TraceID().String(), spanContext.SpanID().String()
+
+	return &traceID, &spanID
+}
@@ -0,0 +1,160 @@
+package service_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andrewshostak/result-service/client"
+	"github.com/andrewshostak/result-service/clock"
+	"github.com/andrewshostak/result-service/errs"
+	"github.com/andrewshostak/result-service/repository"
+	"github.com/andrewshostak/result-service/scheduler"
+	"github.com/andrewshostak/result-service/service"
+	"github.com/andrewshostak/result-service/service/mocks"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/jackc/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	tracingRecorderOnce sync.Once
+	tracingRecorder     *tracetest.SpanRecorder
+	tracingProvider     *sdktrace.TracerProvider
+)
+
+// spanRecorder registers the global tracer provider which records spans. The tracer of the service package
+// is bound to the first registered provider, so the recorder is shared by tests of the package.
+func spanRecorder() (*tracetest.SpanRecorder, *sdktrace.TracerProvider) {
+	tracingRecorderOnce.Do(func() {
+		tracingRecorder = tracetest.NewSpanRecorder()
+		tracingProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(tracingRecorder))
+		otel.SetTracerProvider(tracingProvider)
+	})
+
+	return tracingRecorder, tracingProvider
+}
+
+// endedSpans returns ended spans with the name which are linked to the span context.
+func endedSpans(recorder *tracetest.SpanRecorder, name string, linked trace.SpanContext) []sdktrace.ReadOnlySpan {
+	var spans []sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Name() != name {
+			continue
+		}
+
+		for _, link := range span.Links() {
+			if link.SpanContext.TraceID() == linked.TraceID() && link.SpanContext.SpanID() == linked.SpanID() {
+				spans = append(spans, span)
+			}
+		}
+	}
+
+	return spans
+}
+
+func TestMatchService_Tracing(t *testing.T) {
+	startsAt := time.Date(2024, time.January, 20, 15, 0, 0, 0, time.UTC)
+
+	t.Run("it should save ids of the request span with the created match", func(t *testing.T) {
+		_, provider := spanRecorder()
+		ctx, span := provider.Tracer("test").Start(context.Background(), "POST /matches")
+		defer span.End()
+
+		traceID, spanID := span.SpanContext().TraceID().String(), span.SpanContext().SpanID().String()
+
+		aliasRepository := mocks.NewAliasRepository(t)
+		matchRepository := mocks.NewMatchRepository(t)
+		footballAPIFixtureRepository := mocks.NewFootballAPIFixtureRepository(t)
+		footballAPIClient := mocks.NewFootballAPIClient(t)
+		taskScheduler := mocks.NewTaskScheduler(t)
+		logger := mocks.NewLogger(t)
+
+		logger.On("Info").Return(nil)
+		aliasRepository.On("Find", ctx, "Arsenal").
+			Return(&repository.Alias{TeamID: 1, FootballApiTeam: &repository.FootballApiTeam{ID: 42, TeamID: 1}}, nil).Once()
+		aliasRepository.On("Find", ctx, "Chelsea").
+			Return(&repository.Alias{TeamID: 2, FootballApiTeam: &repository.FootballApiTeam{ID: 49, TeamID: 2}}, nil).Once()
+		matchRepository.On("One", ctx, repository.Match{StartsAt: startsAt, HomeTeamID: 1, AwayTeamID: 2}).
+			Return(nil, errs.MatchNotFoundError{Message: gofakeit.Sentence(2)}).Once()
+		footballAPIClient.On("SearchFixtures", ctx, mock.Anything).Return(&client.FixturesResponse{Response: []client.Result{{
+			Fixture: client.Fixture{ID: 101, Date: "2024-01-20T15:00:00Z", Status: client.Status{Short: "NS", Long: "Not Started"}},
+			Teams:   client.Teams{Home: client.Team{ID: 42}, Away: client.Team{ID: 49}},
+		}}}, nil).Once()
+		matchRepository.On("Create", ctx, repository.Match{HomeTeamID: 1, AwayTeamID: 2, StartsAt: startsAt, TraceID: &traceID, SpanID: &spanID}).
+			Return(&repository.Match{ID: 7, HomeTeamID: 1, AwayTeamID: 2, StartsAt: startsAt, TraceID: &traceID, SpanID: &spanID}, nil).Once()
+		footballAPIFixtureRepository.On("Create", ctx, repository.FootballApiFixture{ID: 101, MatchID: 7}, mock.Anything).
+			Return(&repository.FootballApiFixture{ID: 101, MatchID: 7, Data: pgtype.JSONB{Bytes: []byte(`{}`), Status: pgtype.Present}}, nil).Once()
+		taskScheduler.On("ScheduleOnce", "7-101", mock.Anything, startsAt.Add(115*time.Minute)).Return(nil).Once()
+		matchRepository.On("Update", ctx, uint(7), repository.Scheduled).Return(&repository.Match{ID: 7}, nil).Once()
+
+		ms := newMatchService(t, matchServiceSetup{
+			aliasRepository:              aliasRepository,
+			matchRepository:              matchRepository,
+			footballAPIFixtureRepository: footballAPIFixtureRepository,
+			footballAPIClient:            footballAPIClient,
+			taskScheduler:                taskScheduler,
+			logger:                       logger,
+		})
+
+		id, err := ms.Create(ctx, service.CreateMatchRequest{StartsAt: startsAt, AliasHome: "Arsenal", AliasAway: "Chelsea"})
+		assert.NoError(t, err)
+		assert.Equal(t, uint(7), id)
+	})
+
+	t.Run("it should link spans of polling and saving the result to the request which created the match", func(t *testing.T) {
+		recorder, _ := spanRecorder()
+		creator := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+			SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		})
+
+		matchRepository := mocks.NewMatchRepository(t)
+		resultProvider := mocks.NewResultProvider(t)
+		resultPublisher := mocks.NewResultPublisher(t)
+		logger := mocks.NewLogger(t)
+		fakeClock := clock.NewFake(startsAt)
+
+		logger.On("Info").Return(nil)
+		logger.On("Debug").Return(nil)
+		resultProvider.On("ID").Return("football-api")
+
+		ms := newMatchService(t, matchServiceSetup{
+			matchRepository: matchRepository,
+			resultProviders: []service.ResultProvider{resultProvider},
+			taskScheduler:   scheduler.NewTaskScheduler(fakeClock),
+			resultPublisher: resultPublisher,
+			logger:          logger,
+			clock:           fakeClock,
+		})
+
+		err := ms.ScheduleMatchResultAcquiring(service.Match{
+			ID:                  7,
+			StartsAt:            startsAt,
+			TraceID:             creator.TraceID().String(),
+			SpanID:              creator.SpanID().String(),
+			FootballApiFixtures: []service.FootballAPIFixture{{ID: 101, Round: "Regular Season - 21"}},
+			HomeTeam:            &service.Team{ID: 1, Aliases: []service.Alias{{TeamID: 1, Alias: "Arsenal"}}},
+			AwayTeam:            &service.Team{ID: 2, Aliases: []service.Alias{{TeamID: 2, Alias: "Chelsea"}}},
+		})
+		assert.NoError(t, err)
+
+		finished := service.Data{Fixture: service.Fixture{ID: 101, Status: service.Status{Short: "FT", Long: "Match Finished"}}, Goals: service.Goals{Home: 2, Away: 1}}
+		resultProvider.On("Result", mock.Anything, mock.Anything).Return(&finished, nil).Once()
+		matchRepository.On("SaveResultInTrx", mock.Anything, mock.Anything).Return(nil).Once()
+		resultPublisher.On("PublishMatchResult", mock.Anything, uint(7)).Return(nil).Once()
+		fakeClock.Advance(115 * time.Minute)
+
+		require.Eventually(t, func() bool {
+			return len(endedSpans(recorder, "save match result", creator)) == 1
+		}, time.Second, time.Millisecond)
+		assert.Len(t, endedSpans(recorder, "poll match result", creator), 1)
+	})
+}
@@ -0,0 +1,69 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andrewshostak/result-service/config"
+	"github.com/andrewshostak/result-service/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestSetup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("it should register only the propagator when the exporter is disabled", func(t *testing.T) {
+		provider := otel.GetTracerProvider()
+
+		shutdown, err := tracing.Setup(ctx, config.Tracing{Exporter: tracing.ExporterNone, SampleRatio: 1})
+		require.NoError(t, err)
+
+		assert.Equal(t, provider, otel.GetTracerProvider())
+		assert.ElementsMatch(t, []string{"traceparent", "tracestate", "baggage"}, otel.GetTextMapPropagator().Fields())
+		assert.NoError(t, shutdown(ctx))
+	})
+
+	t.Run("it should register the sdk tracer provider when the exporter is enabled", func(t *testing.T) {
+		shutdown, err := tracing.Setup(ctx, config.Tracing{Exporter: tracing.ExporterStdout, SampleRatio: 0.5})
+		require.NoError(t, err)
+
+		assert.IsType(t, &sdktrace.TracerProvider{}, otel.GetTracerProvider())
+		assert.NoError(t, shutdown(ctx))
+	})
+
+	t.Run("it should return error when the exporter is unknown", func(t *testing.T) {
+		shutdown, err := tracing.Setup(ctx, config.Tracing{Exporter: "jaeger"})
+		assert.EqualError(t, err, "unknown tracing exporter: jaeger")
+		assert.Nil(t, shutdown)
+	})
+}
+
+func TestNewTransport(t *testing.T) {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+	}))
+	defer server.Close()
+
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	provider := sdktrace.NewTracerProvider()
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "test")
+	defer span.End()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	res, err := (&http.Client{Transport: tracing.NewTransport("football-api")}).Do(req)
+	require.NoError(t, err)
+	_ = res.Body.Close()
+
+	assert.Contains(t, traceparent, span.SpanContext().TraceID().String())
+}